@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 
 	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/viper"
@@ -25,8 +26,54 @@ type ConfigContext struct {
 		Spider    string `yaml:"spider"`
 		Tumblebug string `yaml:"tumblebug"`
 	} `yaml:"urls"`
+	Services  map[string]*ServiceConfig `yaml:"services,omitempty"`
+	Request   *RequestConfig            `yaml:"request,omitempty"`
+	Protected bool                      `yaml:"protected,omitempty"` // destructive commands require typing a namespace name
 }
 
+// request defaults of a context (durations eg. "30s", flags override)
+type RequestConfig struct {
+	Timeout      string `yaml:"timeout,omitempty"`
+	Retries      int    `yaml:"retries,omitempty"`
+	RetryBackoff string `yaml:"retry-backoff,omitempty"`
+}
+
+// settings of a service (mcks, spider, tumblebug)
+type ServiceConfig struct {
+	Auth *AuthConfig `yaml:"auth,omitempty"`
+	TLS  *TLSConfig  `yaml:"tls,omitempty"`
+}
+
+// authentication of a service (secret values are references "env:NAME" or "file:PATH")
+type AuthConfig struct {
+	Type     string            `yaml:"type"`
+	Username string            `yaml:"username,omitempty"`
+	Password string            `yaml:"password,omitempty"`
+	Token    string            `yaml:"token,omitempty"`
+	Headers  map[string]string `yaml:"headers,omitempty"`
+}
+
+// TLS of a service (file paths of PEM encoded certificates and a key)
+type TLSConfig struct {
+	CA                 string `yaml:"ca,omitempty"`
+	Cert               string `yaml:"cert,omitempty"`
+	Key                string `yaml:"key,omitempty"`
+	InsecureSkipVerify bool   `yaml:"insecure-skip-verify,omitempty"`
+}
+
+// authentication types
+const (
+	AUTH_NONE   = "none"
+	AUTH_BASIC  = "basic"
+	AUTH_BEARER = "bearer"
+)
+
+// reference prefixes of values
+const (
+	REF_ENV  = "env:"
+	REF_FILE = "file:"
+)
+
 var (
 	Config *conf
 )
@@ -115,6 +162,123 @@ func (self *conf) GetCurrentContext() *ConfigContext {
 	return self.Contexts[self.CurrentContext]
 }
 
+// returns settings of a service (never nil)
+func (self *ConfigContext) Service(name string) *ServiceConfig {
+	if self.Services == nil {
+		self.Services = map[string]*ServiceConfig{}
+	}
+	if self.Services[name] == nil {
+		self.Services[name] = &ServiceConfig{}
+	}
+	return self.Services[name]
+}
+
+// returns authentication of a service (nil if not configured)
+func (self *ConfigContext) GetAuth(service string) *AuthConfig {
+	if self == nil || self.Services[service] == nil {
+		return nil
+	}
+	return self.Services[service].Auth
+}
+
+// returns TLS of a service (nil if not configured)
+func (self *ConfigContext) GetTLS(service string) *TLSConfig {
+	if self == nil || self.Services[service] == nil {
+		return nil
+	}
+	return self.Services[service].TLS
+}
+
+// validates
+func (self *TLSConfig) Validate() error {
+	if (self.Cert == "") != (self.Key == "") {
+		return fmt.Errorf("A client certificate and a key are required together (cert=%s, key=%s)", self.Cert, self.Key)
+	}
+	for _, path := range []string{self.CA, self.Cert, self.Key} {
+		if path == "" {
+			continue
+		}
+		if _, err := os.Stat(ExpandPath(path)); err != nil {
+			return fmt.Errorf("Not found a file (path=%s)", path)
+		}
+	}
+	return nil
+}
+
+// validates
+func (self *AuthConfig) Validate() error {
+	switch self.Type {
+	case AUTH_NONE:
+	case AUTH_BASIC:
+		if self.Username == "" || self.Password == "" {
+			return fmt.Errorf("Username and password are required (type=%s)", self.Type)
+		}
+	case AUTH_BEARER:
+		if self.Token == "" {
+			return fmt.Errorf("Token is required (type=%s)", self.Type)
+		}
+	default:
+		return fmt.Errorf("Invalid authentication type (type=%s, allowed=none|basic|bearer)", self.Type)
+	}
+	for k, v := range map[string]string{"password": self.Password, "token": self.Token} {
+		if v != "" && !IsReference(v) {
+			return fmt.Errorf("A %s must be a reference (env:NAME or file:PATH), not a plaintext", k)
+		}
+	}
+	for k, v := range self.Headers {
+		if IsSecretHeader(k) && !IsReference(v) {
+			return fmt.Errorf("A value of a header %s must be a reference (env:NAME or file:PATH), not a plaintext", k)
+		}
+	}
+	return nil
+}
+
+// words of header names of credentials (eg. Authorization, X-API-Key, X-Auth-Token, Cookie)
+var secretHeaderWords = []string{"auth", "token", "key", "secret", "password", "cookie", "session", "credential", "signature"}
+
+// whether a header is a credential (values must be references)
+func IsSecretHeader(name string) bool {
+	name = strings.ToLower(name)
+	for _, w := range secretHeaderWords {
+		if strings.Contains(name, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// whether a value is a reference of a environment variable or a file
+func IsReference(value string) bool {
+	return strings.HasPrefix(value, REF_ENV) || strings.HasPrefix(value, REF_FILE)
+}
+
+// resolves a reference ("env:NAME", "file:PATH"), other values are returned as it is
+func ResolveValue(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, REF_ENV):
+		name := strings.TrimPrefix(value, REF_ENV)
+		if v, ok := os.LookupEnv(name); ok {
+			return v, nil
+		}
+		return "", fmt.Errorf("Not found a environment variable (name=%s)", name)
+	case strings.HasPrefix(value, REF_FILE):
+		b, err := ioutil.ReadFile(ExpandPath(strings.TrimPrefix(value, REF_FILE)))
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimRight(string(b), "\r\n"), nil
+	}
+	return value, nil
+}
+
+// expands a home directory ("~/")
+func ExpandPath(path string) string {
+	if strings.HasPrefix(path, "~/") {
+		return filepath.Join(HomeDir(), path[2:])
+	}
+	return path
+}
+
 func HomeDir() string {
 
 	if runtime.GOOS == "windows" {
@@ -0,0 +1,30 @@
+package app
+
+import (
+	"testing"
+)
+
+func TestAuthConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		auth    AuthConfig
+		wantErr bool
+	}{
+		{"none", AuthConfig{Type: AUTH_NONE}, false},
+		{"basic", AuthConfig{Type: AUTH_BASIC, Username: "admin", Password: "env:PASSWORD"}, false},
+		{"plaintext password", AuthConfig{Type: AUTH_BASIC, Username: "admin", Password: "pw"}, true},
+		{"bearer", AuthConfig{Type: AUTH_BEARER, Token: "file:~/.cbctl/token"}, false},
+		{"plaintext token", AuthConfig{Type: AUTH_BEARER, Token: "t0k3n"}, true},
+		{"header", AuthConfig{Type: AUTH_NONE, Headers: map[string]string{"X-Tenant": "acornsoft"}}, false},
+		{"header reference", AuthConfig{Type: AUTH_NONE, Headers: map[string]string{"X-API-Key": "env:API_KEY"}}, false},
+		{"plaintext api-key header", AuthConfig{Type: AUTH_NONE, Headers: map[string]string{"X-API-Key": "k3y"}}, true},
+		{"plaintext authorization header", AuthConfig{Type: AUTH_NONE, Headers: map[string]string{"authorization": "Basic dXNlcjpwdw=="}}, true},
+		{"plaintext cookie header", AuthConfig{Type: AUTH_NONE, Headers: map[string]string{"Cookie": "session=1"}}, true},
+		{"unknown type", AuthConfig{Type: "digest"}, true},
+	}
+	for _, tt := range tests {
+		if err := tt.auth.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: Validate() error = %v, want %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
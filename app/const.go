@@ -3,6 +3,7 @@ package app
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/ghodss/yaml"
 
@@ -30,6 +31,15 @@ type Options struct {
 	Filename   string   // file
 	Namespace  string   // cloud-barista namespace
 	Name       string   // object name
+	DryRun     string   // dry-run mode (none/client)
+	Yes        bool     // skip confirmations (--yes)
+	Force      bool     // skip confirmations of protected contexts (--force-protected)
+	Verbosity  int      // log level (-v)
+	Request    struct {
+		Timeout      time.Duration // request timeout (0 : no timeout)
+		Retries      int           // retries of idempotent requests
+		RetryBackoff time.Duration // initial wait time between retries
+	}
 }
 
 func (o *Options) GetFilename() string {
@@ -51,6 +61,7 @@ func (o *Options) PrintlnError(err error) {
 func (o *Options) WriteBody(json []byte) {
 	if o.Output == OUTPUT_JSON {
 		o.OutStream.Write(utils.ToPrettyJSON(json))
+		o.OutStream.WriteString("\n")
 	} else {
 		if d, err := yaml.JSONToYAML(json); err == nil {
 			o.OutStream.Write(d)
@@ -67,8 +78,21 @@ type IOStreams struct {
 }
 
 const (
-	OUTPUT_JSON = "json"
-	OUTPUT_YAML = "yaml"
+	OUTPUT_JSON  = "json"
+	OUTPUT_YAML  = "yaml"
+	OUTPUT_TABLE = "table"
+	OUTPUT_WIDE  = "wide"
+	OUTPUT_NAME  = "name"
+
+	OUTPUT_JSONPATH        = "jsonpath"
+	OUTPUT_GOTEMPLATE      = "go-template"
+	OUTPUT_GOTEMPLATE_FILE = "go-template-file"
+)
+
+// dry-run modes
+const (
+	DRY_RUN_NONE   = "none"
+	DRY_RUN_CLIENT = "client"
 )
 
 type OutputType string
@@ -94,3 +118,16 @@ func (self *Output) WriteString(format string, params ...interface{}) {
 	line := fmt.Sprintf(format, params...)
 	self.Stream.WriteString(line)
 }
+
+// exit codes
+const (
+	EXIT_ERROR      = 1 // general errors
+	EXIT_VALIDATION = 2 // invalid requests (400, 422)
+	EXIT_AUTH       = 3 // unauthorized or forbidden (401, 403)
+	EXIT_NOT_FOUND  = 4 // not found (404)
+	EXIT_CONFLICT   = 5 // already exists (409)
+	EXIT_SERVER     = 6 // server errors (5xx)
+	EXIT_CONNECTION = 7 // connection refused or unreachable
+	EXIT_TIMEOUT    = 8 // timed out
+	EXIT_FAILED     = 9 // an object reached a terminal failure state
+)
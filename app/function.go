@@ -1,7 +1,11 @@
 package app
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
@@ -10,6 +14,7 @@ import (
 
 	"github.com/ghodss/yaml"
 	"github.com/spf13/cobra"
+	yamlv3 "gopkg.in/yaml.v3"
 
 	"github.com/itnpeople/cbctl/utils"
 )
@@ -17,17 +22,52 @@ import (
 func ValidateError(c *cobra.Command, err error) {
 
 	if err != nil {
-		c.Help()
+		code := EXIT_ERROR
 		msg := "\n" + err.Error()
+
+		// errors of API calls (not a usage error)
+		var e interface{ ExitCode() int }
+		if errors.As(err, &e) {
+			code = e.ExitCode()
+			msg = "Error: " + err.Error()
+		} else {
+			c.Help()
+		}
 		if !strings.HasSuffix(msg, "\n") {
 			msg += "\n"
 		}
 		fmt.Fprint(os.Stderr, msg)
-		os.Exit(1)
+		os.Exit(code)
 	}
 
 }
 
+// an error with a exit code
+type ExitError struct {
+	Code    int
+	Message string
+}
+
+func (e *ExitError) Error() string {
+	return e.Message
+}
+
+func (e *ExitError) ExitCode() int {
+	return e.Code
+}
+
+// adds a "--dry-run" flag (none/client, "--dry-run" without a value means "client")
+func (o *Options) AddDryRunFlag(c *cobra.Command) {
+	c.PersistentFlags().StringVar(&o.DryRun, "dry-run", DRY_RUN_NONE, "Print requests without sending (none/client)")
+	c.PersistentFlags().Lookup("dry-run").NoOptDefVal = DRY_RUN_CLIENT
+}
+
+// adds "--yes" and "--force-protected" flags to skip confirmations
+func (o *Options) AddYesFlag(c *cobra.Command) {
+	c.PersistentFlags().BoolVarP(&o.Yes, "yes", "y", false, "Skip confirmations on a terminal (non-interactive sessions are not prompted, except protected contexts)")
+	c.PersistentFlags().BoolVar(&o.Force, "force-protected", false, "Skip confirmations of protected contexts (required in non-interactive sessions)")
+}
+
 func BindCommandArgs(values ...*string) func(c *cobra.Command, args []string) error {
 
 	return func(c *cobra.Command, args []string) error {
@@ -49,21 +89,7 @@ func GetBody(o IOptions, tpl string) (buf []byte, err error) {
 	// -f 옵션
 	fileName := o.GetFilename()
 	if len(fileName) > 0 {
-		switch {
-		case fileName == "-": // standard-in
-			buf, err = ioutil.ReadAll(os.Stdin)
-		case strings.Index(fileName, "http://") == 0 || strings.Index(fileName, "https://") == 0: // http
-			if _, err = url.Parse(fileName); err == nil {
-				if resp, err := http.Get(fileName); err == nil {
-					defer resp.Body.Close()
-					buf, err = ioutil.ReadAll(resp.Body)
-				}
-			}
-		default:
-			buf, err = ioutil.ReadFile(fileName) // local file
-		}
-
-		if err == nil {
+		if buf, err = ReadFile(fileName); err == nil {
 			buf, err = yaml.YAMLToJSON(buf)
 		}
 	} else {
@@ -72,3 +98,47 @@ func GetBody(o IOptions, tpl string) (buf []byte, err error) {
 
 	return
 }
+
+// reads a file (standard-in "-", http(s) url or local file)
+func ReadFile(fileName string) (buf []byte, err error) {
+
+	switch {
+	case fileName == "-": // standard-in
+		buf, err = ioutil.ReadAll(os.Stdin)
+	case strings.Index(fileName, "http://") == 0 || strings.Index(fileName, "https://") == 0: // http
+		if _, err = url.Parse(fileName); err == nil {
+			var resp *http.Response
+			if resp, err = http.Get(fileName); err == nil {
+				defer resp.Body.Close()
+				buf, err = ioutil.ReadAll(resp.Body)
+			}
+		}
+	default:
+		buf, err = ioutil.ReadFile(fileName) // local file
+	}
+	return
+}
+
+// splits a multi-document yaml (separated by "---") into json documents
+func SplitDocuments(buf []byte) ([][]byte, error) {
+
+	docs := [][]byte{}
+	decoder := yamlv3.NewDecoder(bytes.NewReader(buf))
+	for i := 1; ; i++ {
+		var doc interface{}
+		if err := decoder.Decode(&doc); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("Invalid yaml document (document=%d, cause=%v)", i, err)
+		}
+		if doc == nil {
+			continue // empty document
+		}
+		b, err := json.Marshal(doc)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid yaml document (document=%d, cause=%v)", i, err)
+		}
+		docs = append(docs, b)
+	}
+	return docs, nil
+}
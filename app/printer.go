@@ -0,0 +1,244 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/client-go/util/jsonpath"
+
+	"github.com/itnpeople/cbctl/utils"
+)
+
+// an object printable as a table (-o table, -o wide)
+type TableObject interface {
+	Columns(wide bool) []string
+	Rows(wide bool) [][]string
+}
+
+// an object has a kind and a name (-o name)
+type NamedObject interface {
+	GetKind() string
+	GetName() string
+}
+
+// a list of objects
+type ListObject interface {
+	GetItems() []interface{}
+}
+
+// an object of a response body (a raw body is written as is by yaml and json formats)
+type RawObject interface {
+	RawBody() []byte
+}
+
+// an object written in dry-run mode (a request not sent)
+type DryRunObject interface {
+	IsDryRun() bool
+}
+
+// validates a dry-run flag
+func (o *Options) ValidateDryRun() error {
+	switch o.DryRun {
+	case "", DRY_RUN_NONE, DRY_RUN_CLIENT:
+		return nil
+	}
+	return fmt.Errorf("Invalid dry-run mode (dry-run=%s, allowed=none|client)", o.DryRun)
+}
+
+// whether requests are only printed, not sent
+func (o *Options) IsDryRun() bool {
+	return o.DryRun == DRY_RUN_CLIENT
+}
+
+// splits a output flag into a format and a template (eg. "jsonpath={.items[*].name}")
+func (o *Options) OutputFormat() (format string, tpl string) {
+	if idx := strings.Index(o.Output, "="); idx > 0 {
+		return o.Output[:idx], o.Output[idx+1:]
+	}
+	return o.Output, ""
+}
+
+// validates a output flag
+func (o *Options) ValidateOutput() error {
+	format, tpl := o.OutputFormat()
+	switch format {
+	case OUTPUT_TABLE, OUTPUT_WIDE, OUTPUT_YAML, OUTPUT_JSON, OUTPUT_NAME:
+		return nil
+	case OUTPUT_JSONPATH:
+		_, err := parseJSONPath(tpl)
+		return err
+	case OUTPUT_GOTEMPLATE, OUTPUT_GOTEMPLATE_FILE:
+		_, err := parseGoTemplate(format, tpl)
+		return err
+	}
+	return fmt.Errorf("Invalid output format (output=%s, allowed=table|wide|yaml|json|name|jsonpath=...|go-template=...|go-template-file=...)", o.Output)
+}
+
+// writes an object in the output format (returns an error if a template fails to execute)
+func (o *Options) WriteObject(obj interface{}) error {
+
+	// results are empty in dry-run mode
+	if o.IsDryRun() {
+		if d, ok := obj.(DryRunObject); !ok || !d.IsDryRun() {
+			return nil
+		}
+	}
+
+	format, tpl := o.OutputFormat()
+	switch format {
+	case OUTPUT_TABLE, OUTPUT_WIDE:
+		if t, ok := obj.(TableObject); ok {
+			o.WriteTable(t)
+			return nil
+		}
+	case OUTPUT_NAME:
+		if o.writeNames(obj) {
+			return nil
+		}
+	case OUTPUT_JSONPATH, OUTPUT_GOTEMPLATE, OUTPUT_GOTEMPLATE_FILE:
+		if err := o.writeTemplate(obj, format, tpl); err != nil {
+			return &ExitError{Code: EXIT_ERROR, Message: fmt.Sprintf("Unable to execute a template (output=%s, cause=%v)", o.Output, err)}
+		}
+		return nil
+	}
+
+	// yaml, json (default), a response body as is
+	if r, ok := obj.(RawObject); ok && len(r.RawBody()) > 0 {
+		o.WriteBody(r.RawBody())
+	} else if b, err := json.Marshal(obj); err != nil {
+		return &ExitError{Code: EXIT_ERROR, Message: fmt.Sprintf("Unable to write an object (cause=%v)", err)}
+	} else {
+		o.WriteBody(b)
+	}
+	return nil
+}
+
+func (o *Options) WriteTable(obj TableObject) {
+	wide := (o.Output == OUTPUT_WIDE)
+	rows := obj.Rows(wide)
+	if len(rows) == 0 {
+		fmt.Fprintln(os.Stderr, "No resources found.")
+		return
+	}
+	PrintTable(o.OutStream, obj.Columns(wide), rows)
+}
+
+// prints "kind/name" lines (returns false if the object has no names)
+func (o *Options) writeNames(obj interface{}) bool {
+	items := []interface{}{obj}
+	if list, ok := obj.(ListObject); ok {
+		items = list.GetItems()
+	} else if _, ok := obj.(NamedObject); !ok {
+		return false
+	}
+	for _, item := range items {
+		if n, ok := item.(NamedObject); ok {
+			o.Println("%s/%s", n.GetKind(), n.GetName())
+		}
+	}
+	return true
+}
+
+// prints a object using a JSONPath or Go template
+func (o *Options) writeTemplate(obj interface{}, format string, tpl string) error {
+
+	data, err := toGeneric(obj)
+	if err != nil {
+		return err
+	}
+	if format == OUTPUT_JSONPATH {
+		j, err := parseJSONPath(tpl)
+		if err != nil {
+			return err
+		}
+		if err := j.Execute(o.OutStream, data); err != nil {
+			return err
+		}
+		o.Println("")
+		return nil
+	}
+
+	t, err := parseGoTemplate(format, tpl)
+	if err != nil {
+		return err
+	}
+	return t.Execute(o.OutStream, data)
+}
+
+func parseJSONPath(tpl string) (*jsonpath.JSONPath, error) {
+	if tpl == "" {
+		return nil, fmt.Errorf("JSONPath template is required (eg. -o jsonpath='{.items[*].name}')")
+	}
+	if !strings.Contains(tpl, "{") {
+		tpl = "{" + tpl + "}"
+	}
+	j := jsonpath.New("output").AllowMissingKeys(true)
+	if err := j.Parse(tpl); err != nil {
+		return nil, fmt.Errorf("Invalid JSONPath template (template=%s, cause=%v)", tpl, err)
+	}
+	return j, nil
+}
+
+func parseGoTemplate(format string, tpl string) (*template.Template, error) {
+	if format == OUTPUT_GOTEMPLATE_FILE {
+		b, err := ioutil.ReadFile(tpl)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read a template file (file=%s, cause=%v)", tpl, err)
+		}
+		tpl = string(b)
+	}
+	if tpl == "" {
+		return nil, fmt.Errorf("Go template is required (eg. -o go-template='{{range .items}}{{.name}}{{end}}')")
+	}
+	t, err := template.New("output").Parse(tpl)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid Go template (cause=%v)", err)
+	}
+	return t, nil
+}
+
+// converts a object to a generic (map, slice) JSON value
+func toGeneric(obj interface{}) (interface{}, error) {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var data interface{}
+	err = json.Unmarshal(b, &data)
+	return data, err
+}
+
+// prints rows aligned by columns
+func PrintTable(out io.Writer, columns []string, rows [][]string) {
+	w := tabwriter.NewWriter(out, 6, 4, 3, ' ', 0)
+	if len(columns) > 0 {
+		fmt.Fprintln(w, strings.Join(columns, "\t"))
+	}
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	w.Flush()
+}
+
+// returns a human-readable age of the timestamp
+func Age(timestamp string) string {
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02T15:04:05"} {
+		if t, err := time.Parse(layout, timestamp); err == nil {
+			return duration.HumanDuration(time.Since(t))
+		}
+	}
+	return "<unknown>"
+}
+
+// returns "<none>" if the value is empty
+func None(value string) string {
+	return utils.NVL(value, "<none>")
+}
@@ -0,0 +1,213 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+)
+
+const PROFILE_EXT = ".yaml"
+
+var profileNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][-a-zA-Z0-9_.]*$`)
+
+// a node-pool of a cluster (nodes of a connection and a spec)
+type NodePool struct {
+	Connection string `json:"connection"`
+	Count      int    `json:"count"`
+	Spec       string `json:"spec"`
+}
+
+// a named cluster spec (same fields as a body of "create cluster -f" without a name)
+type ClusterProfile struct {
+	Name         string     `json:"name,omitempty"`
+	Label        string     `json:"label,omitempty"`
+	Description  string     `json:"description,omitempty"`
+	ControlPlane []NodePool `json:"controlPlane,omitempty"`
+	Worker       []NodePool `json:"worker,omitempty"`
+	Config       struct {
+		Kubernetes struct {
+			Version          string `json:"version,omitempty"`
+			NetworkCni       string `json:"networkCni,omitempty"`
+			PodCidr          string `json:"podCidr,omitempty"`
+			ServiceCidr      string `json:"serviceCidr,omitempty"`
+			ServiceDnsDomain string `json:"serviceDnsDomain,omitempty"`
+		} `json:"kubernetes"`
+	} `json:"config"`
+}
+
+// a list of cluster profiles (and profiles failed to load)
+type ClusterProfileList struct {
+	Items   []ClusterProfile `json:"items"`
+	Invalid []InvalidProfile `json:"invalid,omitempty"`
+}
+
+// a profile failed to load
+type InvalidProfile struct {
+	Name  string `json:"name"`
+	Error string `json:"error"`
+}
+
+// returns a directory of cluster profiles (~/.cbctl/profiles)
+func ProfileDir() string {
+	return filepath.Join(HomeDir(), ".cbctl", "profiles")
+}
+
+func profilePath(name string) (string, error) {
+	if !profileNameRegexp.MatchString(name) {
+		return "", fmt.Errorf("Invalid profile name (name=%s)", name)
+	}
+	return filepath.Join(ProfileDir(), name+PROFILE_EXT), nil
+}
+
+// parses a profile (yaml or json, unknown fields are not allowed)
+func ParseProfile(name string, buf []byte) (*ClusterProfile, error) {
+
+	b, err := yaml.YAMLToJSON(buf)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid profile (name=%s, cause=%v)", name, err)
+	}
+	profile := &ClusterProfile{}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(profile); err != nil {
+		return nil, fmt.Errorf("Invalid profile (name=%s, cause=%v)", name, err)
+	}
+	profile.Name = name
+	if err := profile.Validate(); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// validates node-pools of a profile
+func (profile *ClusterProfile) Validate() error {
+	for _, pool := range append(append([]NodePool{}, profile.ControlPlane...), profile.Worker...) {
+		if pool.Connection == "" || pool.Spec == "" || pool.Count < 1 {
+			return fmt.Errorf("Invalid profile, a node-pool requires a connection, a spec and a positive count (name=%s, connection=%s, spec=%s, count=%d)", profile.Name, pool.Connection, pool.Spec, pool.Count)
+		}
+	}
+	return nil
+}
+
+// loads a profile of the profile directory
+func LoadProfile(name string) (*ClusterProfile, error) {
+
+	path, err := profilePath(name)
+	if err != nil {
+		return nil, err
+	}
+	buf, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, &ExitError{Code: EXIT_NOT_FOUND, Message: fmt.Sprintf("Not found a profile (name=%s, dir=%s)", name, ProfileDir())}
+	} else if err != nil {
+		return nil, err
+	}
+	return ParseProfile(name, buf)
+}
+
+// loads all profiles of the profile directory (in order of names, invalid profiles are listed separately)
+func ListProfiles() (*ClusterProfileList, error) {
+
+	list := &ClusterProfileList{Items: []ClusterProfile{}}
+	files, err := ioutil.ReadDir(ProfileDir())
+	if os.IsNotExist(err) {
+		return list, nil
+	} else if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, f := range files {
+		if !f.IsDir() && strings.HasSuffix(f.Name(), PROFILE_EXT) {
+			names = append(names, strings.TrimSuffix(f.Name(), PROFILE_EXT))
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		profile, err := LoadProfile(name)
+		if err != nil {
+			list.Invalid = append(list.Invalid, InvalidProfile{Name: name, Error: err.Error()})
+			continue
+		}
+		list.Items = append(list.Items, *profile)
+	}
+	return list, nil
+}
+
+// saves a valid profile into the profile directory (fails if exists unless overwrite)
+func SaveProfile(profile *ClusterProfile, overwrite bool) error {
+
+	path, err := profilePath(profile.Name)
+	if err != nil {
+		return err
+	}
+	if err := profile.Validate(); err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); err == nil && !overwrite {
+		return &ExitError{Code: EXIT_CONFLICT, Message: fmt.Sprintf("A profile already exists (name=%s, path=%s)", profile.Name, path)}
+	}
+	saved := *profile
+	saved.Name = "" // a file name is a name of the profile
+	b, err := json.Marshal(saved)
+	if err != nil {
+		return err
+	}
+	if b, err = yaml.JSONToYAML(b); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(ProfileDir(), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, 0644)
+}
+
+func (obj *ClusterProfile) GetKind() string {
+	return "profile"
+}
+
+func (obj *ClusterProfile) GetName() string {
+	return obj.Name
+}
+
+func (obj *ClusterProfileList) GetItems() []interface{} {
+	items := []interface{}{}
+	for i := range obj.Items {
+		items = append(items, &obj.Items[i])
+	}
+	return items
+}
+
+func (obj *ClusterProfileList) Columns(wide bool) []string {
+	return []string{"NAME", "CONTROL-PLANE", "WORKERS", "NETWORK-CNI", "LABEL", "ERROR"}
+}
+
+func (obj *ClusterProfileList) Rows(wide bool) [][]string {
+	rows := [][]string{}
+	for _, p := range obj.Items {
+		rows = append(rows, []string{p.Name, pools(p.ControlPlane), pools(p.Worker), None(p.Config.Kubernetes.NetworkCni), None(p.Label), None("")})
+	}
+	for _, p := range obj.Invalid {
+		rows = append(rows, []string{p.Name, None(""), None(""), None(""), None(""), p.Error})
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i][0] < rows[j][0]
+	})
+	return rows
+}
+
+// returns a summary of node-pools (eg. "config-aws-tokyo/t2.medium x2, config-gcp-tokyo/e2-medium x1")
+func pools(list []NodePool) string {
+	s := []string{}
+	for _, p := range list {
+		s = append(s, fmt.Sprintf("%s/%s x%d", p.Connection, p.Spec, p.Count))
+	}
+	return None(strings.Join(s, ", "))
+}
@@ -0,0 +1,61 @@
+package app
+
+import (
+	"testing"
+)
+
+func TestParseProfile(t *testing.T) {
+	buf := []byte(`
+label: dev
+controlPlane:
+- connection: config-aws-tokyo
+  count: 1
+  spec: t2.medium
+worker:
+- connection: config-gcp-tokyo
+  count: 2
+  spec: e2-medium
+config:
+  kubernetes:
+    networkCni: kilo
+`)
+	profile, err := ParseProfile("dev", buf)
+	if err != nil {
+		t.Fatalf("ParseProfile() : %v", err)
+	}
+	if profile.Name != "dev" || profile.Label != "dev" || profile.Config.Kubernetes.NetworkCni != "kilo" {
+		t.Errorf("ParseProfile() = %+v", profile)
+	}
+	if len(profile.Worker) != 1 || profile.Worker[0] != (NodePool{Connection: "config-gcp-tokyo", Count: 2, Spec: "e2-medium"}) {
+		t.Errorf("ParseProfile() workers = %+v", profile.Worker)
+	}
+}
+
+func TestParseProfileInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		buf  string
+	}{
+		{"unknown field", "workers: []"},
+		{"not yaml", "worker: ["},
+		{"no connection", "worker: [{count: 1, spec: t2.medium}]"},
+		{"no spec", "worker: [{connection: config-aws-tokyo, count: 1}]"},
+		{"zero count", "controlPlane: [{connection: config-aws-tokyo, count: 0, spec: t2.medium}]"},
+	}
+	for _, tt := range tests {
+		if _, err := ParseProfile("p", []byte(tt.buf)); err == nil {
+			t.Errorf("%s: ParseProfile() expected an error", tt.name)
+		}
+	}
+}
+
+func TestProfilePath(t *testing.T) {
+	for _, name := range []string{"", "../p", "a/b", ".p"} {
+		if _, err := profilePath(name); err == nil {
+			t.Errorf("profilePath(%q) expected an error", name)
+		}
+	}
+	if _, err := profilePath("dev-1.2_x"); err != nil {
+		t.Errorf("profilePath() : %v", err)
+	}
+}
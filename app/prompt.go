@@ -0,0 +1,102 @@
+package app
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// whether the standard-in is a terminal
+func IsTerminal() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// reads a secret from the terminal without echo (a prompt goes to stderr)
+func ReadSecret(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	b, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// reads all of the standard-in (a trailing new-line is removed)
+func ReadStdin() (string, error) {
+	b, err := ioutil.ReadAll(os.Stdin)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(b), "\r\n"), nil
+}
+
+// reads a line from the terminal (a prompt goes to stderr)
+func ReadLine(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// a confirmation of a destructive operation
+type Confirmation struct {
+	Action    string   // eg. "deleted"
+	Namespace string   // a namespace of objects (empty if objects are not in a namespace)
+	Objects   []string // objects affected ("kind/name")
+}
+
+// whether a confirmation is asked (not skipped by dry-run, "--yes" or "--force-protected" of a protected context)
+func (o *Options) Prompts() bool {
+	if o.IsDryRun() {
+		return false
+	}
+	if ctx := Config.GetCurrentContext(); ctx != nil && ctx.Protected {
+		return !o.Force
+	}
+	return !o.Yes
+}
+
+// asks a confirmation of a destructive operation on the terminal, skipped by "--yes", dry-run or non-interactive sessions
+// a protected context requires typing a namespace name (a context name if no namespace) even with "--yes", skipped by "--force-protected" only (required in non-interactive sessions)
+func (o *Options) Confirm(c Confirmation) (bool, error) {
+	if !o.Prompts() {
+		return true, nil
+	}
+	ctx := Config.GetCurrentContext()
+	protected := ctx != nil && ctx.Protected
+	if !IsTerminal() {
+		if protected {
+			return false, &ExitError{Code: EXIT_ERROR, Message: fmt.Sprintf("The context is protected, use --force-protected in non-interactive sessions (context=%s)", Config.CurrentContext)}
+		}
+		return true, nil
+	}
+
+	fmt.Fprintf(os.Stderr, "The following objects will be %s (count=%d, context=%s, namespace=%s)\n", c.Action, len(c.Objects), Config.CurrentContext, None(c.Namespace))
+	for _, obj := range c.Objects {
+		fmt.Fprintf(os.Stderr, "  %s\n", obj)
+	}
+	if protected {
+		name := c.Namespace
+		if name == "" {
+			name = Config.CurrentContext
+		}
+		answer, err := ReadLine(fmt.Sprintf("The context %q is protected, type %q to confirm: ", Config.CurrentContext, name))
+		if err != nil {
+			return false, err
+		}
+		return answer == name, nil
+	}
+	answer, err := ReadLine("Continue? [y/N]: ")
+	if err != nil {
+		return false, err
+	}
+	answer = strings.ToLower(answer)
+	return answer == "y" || answer == "yes", nil
+}
@@ -0,0 +1,176 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+)
+
+const WATCH_TIME_FORMAT = "2006-01-02 15:04:05"
+
+// a result of a fetch
+type fetched struct {
+	obj interface{}
+	err error
+}
+
+// polls objects at the interval and prints only changed rows or objects (until interrupted, also while a fetch is running)
+func (o *Options) Watch(interval time.Duration, fetch func() (interface{}, error)) error {
+
+	if interval <= 0 {
+		return fmt.Errorf("Invalid watch interval (interval=%v)", interval)
+	}
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(stop)
+
+	format, _ := o.OutputFormat()
+	table := (format == OUTPUT_TABLE || format == OUTPUT_WIDE)
+	w := tabwriter.NewWriter(o.OutStream, 6, 4, 3, ' ', 0)
+
+	last := map[string]string{}
+	lastErr := ""
+	for i := 0; ; i++ {
+		// a hanging fetch is abandoned if interrupted
+		result := make(chan fetched, 1)
+		go func() {
+			obj, err := fetch()
+			result <- fetched{obj, err}
+		}()
+		var obj interface{}
+		var err error
+		select {
+		case <-stop:
+			return nil
+		case r := <-result:
+			obj, err = r.obj, r.err
+		}
+		now := time.Now().Format(WATCH_TIME_FORMAT)
+		if err != nil {
+			if i == 0 {
+				return err
+			}
+			if err.Error() != lastErr {
+				fmt.Fprintf(os.Stderr, "%s\t%v\n", now, err)
+			}
+			lastErr = err.Error()
+		} else {
+			lastErr = ""
+			if t, ok := obj.(TableObject); ok && table {
+				last = o.watchRows(w, t, last, now, i == 0)
+			} else if last, err = o.watchObjects(obj, last, now); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-stop:
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// prints changed rows (keyed by the first column) with a timestamp column
+func (o *Options) watchRows(w *tabwriter.Writer, obj TableObject, last map[string]string, now string, header bool) map[string]string {
+
+	wide := (o.Output == OUTPUT_WIDE)
+	columns := []string{}
+	if header {
+		columns = append([]string{"TIME"}, obj.Columns(wide)...)
+	}
+
+	// an age column is not a change
+	age := -1
+	for i, c := range obj.Columns(wide) {
+		if c == "AGE" {
+			age = i
+		}
+	}
+
+	current := map[string]string{}
+	rows := [][]string{}
+	for _, row := range obj.Rows(wide) {
+		values := []string{}
+		for i, v := range row {
+			if i != age {
+				values = append(values, v)
+			}
+		}
+		line := strings.Join(values, "\t")
+		current[row[0]] = line
+		if last[row[0]] != line {
+			rows = append(rows, append([]string{now}, row...))
+		}
+	}
+	for _, name := range sortedKeys(last) {
+		if _, ok := current[name]; !ok {
+			rows = append(rows, []string{now, name, "(deleted)"})
+		}
+	}
+	if len(columns) > 0 {
+		fmt.Fprintln(w, strings.Join(columns, "\t"))
+	}
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	w.Flush()
+	return current
+}
+
+// prints changed objects (a timestamp goes to stderr to keep stdout parsable, fails if a template fails)
+func (o *Options) watchObjects(obj interface{}, last map[string]string, now string) (map[string]string, error) {
+
+	items := map[string]interface{}{"": obj}
+	if list, ok := obj.(ListObject); ok {
+		items = map[string]interface{}{}
+		for _, item := range list.GetItems() {
+			if n, ok := item.(NamedObject); ok {
+				items[n.GetName()] = item
+			}
+		}
+	}
+
+	current := map[string]string{}
+	for _, name := range sortedKeys(items) {
+		item := items[name]
+		b, _ := json.Marshal(item)
+		current[name] = string(b)
+		if last[name] != current[name] {
+			fmt.Fprintf(os.Stderr, "# %s\n", now)
+			if err := o.WriteObject(item); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, name := range sortedKeys(last) {
+		if _, ok := current[name]; !ok {
+			fmt.Fprintf(os.Stderr, "# %s %s (deleted)\n", now, name)
+		}
+	}
+	return current, nil
+}
+
+// returns sorted keys of a map (a stable order of watch output)
+func sortedKeys(m interface{}) []string {
+	keys := []string{}
+	switch m := m.(type) {
+	case map[string]string:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]interface{}:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
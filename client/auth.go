@@ -0,0 +1,51 @@
+package client
+
+import (
+	"github.com/itnpeople/cbctl/app"
+)
+
+// default basic authentication of CB-Tumblebug
+const (
+	TUMBLEBUG_USERNAME = "default"
+	TUMBLEBUG_PASSWORD = "default"
+)
+
+// applies authentication settings of the context to requests
+func (c *Client) setAuth() error {
+
+	auth := c.Context.GetAuth(c.Service)
+	if auth == nil {
+		if c.Service == SERVICE_TUMBLEBUG {
+			c.http.SetBasicAuth(TUMBLEBUG_USERNAME, TUMBLEBUG_PASSWORD)
+		}
+		return nil
+	}
+	if err := auth.Validate(); err != nil {
+		return err
+	}
+
+	switch auth.Type {
+	case app.AUTH_BASIC:
+		password, err := app.ResolveValue(auth.Password)
+		if err != nil {
+			return err
+		}
+		c.http.SetBasicAuth(auth.Username, password)
+	case app.AUTH_BEARER:
+		token, err := app.ResolveValue(auth.Token)
+		if err != nil {
+			return err
+		}
+		c.http.SetAuthToken(token)
+	}
+
+	// custom headers
+	for k, v := range auth.Headers {
+		value, err := app.ResolveValue(v)
+		if err != nil {
+			return err
+		}
+		c.http.SetHeader(k, value)
+	}
+	return nil
+}
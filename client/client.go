@@ -0,0 +1,100 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+
+	"github.com/itnpeople/cbctl/app"
+)
+
+const (
+	SERVICE_MCKS      = "mcks"
+	SERVICE_SPIDER    = "spider"
+	SERVICE_TUMBLEBUG = "tumblebug"
+)
+
+// a REST client of a cloud-barista service
+type Client struct {
+	Context *app.ConfigContext
+	Service string
+	Url     string
+	http    *resty.Client
+	err     error // an error of client settings (returned by requests)
+}
+
+func newClient(ctx *app.ConfigContext, service string, url string) *Client {
+	c := &Client{
+		Context: ctx,
+		Service: service,
+		Url:     strings.TrimSuffix(url, "/"),
+		http:    resty.New().SetDisableWarn(true).SetLogger(&restyLogger{}),
+	}
+	if err := c.setAuth(); err != nil {
+		c.err = &app.ExitError{Code: app.EXIT_ERROR, Message: fmt.Sprintf("%s: invalid authentication of the context '%s' (cause=%v)", service, ctx.Name, err)}
+	} else if err := c.setTLS(); err != nil {
+		c.err = &app.ExitError{Code: app.EXIT_ERROR, Message: fmt.Sprintf("%s: invalid TLS of the context '%s' (cause=%v)", service, ctx.Name, err)}
+	} else if err := c.setRequest(); err != nil {
+		c.err = &app.ExitError{Code: app.EXIT_ERROR, Message: fmt.Sprintf("%s: invalid request settings of the context '%s' (cause=%v)", service, ctx.Name, err)}
+	}
+	return c
+}
+
+// returns a new request
+func (c *Client) R() *resty.Request {
+	return c.http.R()
+}
+
+// sends a request and unmarshals a response body into the result (returns a nil response in dry-run mode, reads are sent)
+func (c *Client) Execute(method string, path string, body interface{}, result interface{}) (*resty.Response, error) {
+
+	if c.err != nil {
+		return nil, c.err
+	}
+	if DryRun != nil && method != resty.MethodGet {
+		DryRun(c.newRequest(method, path, body))
+		return nil, nil
+	}
+
+	req := c.R()
+	if body != nil {
+		req.SetHeader("content-type", "application/json").SetBody(body)
+	}
+	if result != nil {
+		req.SetResult(result)
+	}
+
+	start := time.Now()
+	resp, err := req.Execute(method, c.Url+path)
+	c.trace(method, c.Url+path, body, resp, err, time.Since(start))
+	if err != nil {
+		if resp == nil || resp.RawResponse == nil {
+			return resp, &ConnectionError{Service: c.Service, Url: c.Url + path, Err: err}
+		}
+		return resp, err
+	}
+	if resp.IsError() {
+		return resp, newAPIError(c.Service, resp)
+	}
+	if r, ok := result.(rawResult); ok {
+		r.setRawBody(resp.Body())
+	}
+	return resp, nil
+}
+
+func (c *Client) get(path string, result interface{}) error {
+	_, err := c.Execute(resty.MethodGet, path, nil, result)
+	return err
+}
+
+func (c *Client) post(path string, body interface{}, result interface{}) error {
+	_, err := c.Execute(resty.MethodPost, path, body, result)
+	return err
+}
+
+func (c *Client) delete(path string, result interface{}) error {
+	_, err := c.Execute(resty.MethodDelete, path, nil, result)
+	return err
+}
@@ -0,0 +1,40 @@
+package client
+
+// a hook of dry-run mode (requests except reads are passed to the hook instead of being sent)
+var DryRun func(req *Request)
+
+// a request not sent (dry-run)
+type Request struct {
+	Kind    string      `json:"kind"`
+	Context string      `json:"context"`
+	Service string      `json:"service"`
+	Method  string      `json:"method"`
+	Url     string      `json:"url"`
+	Body    interface{} `json:"body,omitempty"`
+}
+
+func (c *Client) newRequest(method string, path string, body interface{}) *Request {
+	req := &Request{
+		Kind:    "Request",
+		Service: c.Service,
+		Method:  method,
+		Url:     c.Url + path,
+		Body:    Redact(body),
+	}
+	if c.Context != nil {
+		req.Context = c.Context.Name
+	}
+	return req
+}
+
+func (r *Request) IsDryRun() bool {
+	return true
+}
+
+func (r *Request) GetKind() string {
+	return "request"
+}
+
+func (r *Request) GetName() string {
+	return r.Method + " " + r.Url
+}
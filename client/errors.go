@@ -0,0 +1,152 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+
+	"github.com/itnpeople/cbctl/app"
+)
+
+type Reason string
+
+const (
+	REASON_NOT_FOUND  Reason = "NotFound"
+	REASON_CONFLICT   Reason = "Conflict"
+	REASON_VALIDATION Reason = "Invalid"
+	REASON_AUTH       Reason = "Unauthorized"
+	REASON_SERVER     Reason = "ServerError"
+	REASON_UNKNOWN    Reason = "Unknown"
+)
+
+// an error response of MCKS, CB-Spider or CB-Tumblebug
+type APIError struct {
+	Service    string
+	Method     string
+	Url        string
+	StatusCode int
+	Reason     Reason
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s: %s (status=%d, reason=%s, url=%s %s)", e.Service, e.Message, e.StatusCode, e.Reason, e.Method, e.Url)
+}
+
+func (e *APIError) ExitCode() int {
+	switch e.Reason {
+	case REASON_NOT_FOUND:
+		return app.EXIT_NOT_FOUND
+	case REASON_CONFLICT:
+		return app.EXIT_CONFLICT
+	case REASON_VALIDATION:
+		return app.EXIT_VALIDATION
+	case REASON_AUTH:
+		return app.EXIT_AUTH
+	case REASON_SERVER:
+		return app.EXIT_SERVER
+	}
+	return app.EXIT_ERROR
+}
+
+// an error that a request could not reach the service
+type ConnectionError struct {
+	Service string
+	Url     string
+	Err     error
+}
+
+func (e *ConnectionError) Error() string {
+	return fmt.Sprintf("%s: unable to connect to %s (cause=%v)", e.Service, e.Url, e.Err)
+}
+
+func (e *ConnectionError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ConnectionError) ExitCode() int {
+	var netErr net.Error
+	if errors.As(e.Err, &netErr) && netErr.Timeout() {
+		return app.EXIT_TIMEOUT
+	}
+	return app.EXIT_CONNECTION
+}
+
+// parses an error envelope
+//
+//	MCKS          : {"kind": "Status", "code": 404, "message": "..."}
+//	CB-Spider     : {"message": "..."}
+//	CB-Tumblebug  : {"message": "..."}
+func newAPIError(service string, resp *resty.Response) *APIError {
+
+	e := &APIError{
+		Service:    service,
+		Method:     resp.Request.Method,
+		Url:        resp.Request.URL,
+		StatusCode: resp.StatusCode(),
+	}
+
+	envelope := &struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}{}
+	if err := json.Unmarshal(resp.Body(), envelope); err == nil && envelope.Message != "" {
+		e.Message = envelope.Message
+		if envelope.Code >= 400 && e.StatusCode < 400 {
+			e.StatusCode = envelope.Code
+		}
+	} else {
+		e.Message = strings.TrimSpace(string(resp.Body()))
+	}
+	if e.Message == "" {
+		e.Message = http.StatusText(e.StatusCode)
+	}
+	e.Reason = reasonOf(e.StatusCode, e.Message)
+
+	return e
+}
+
+// classifies an error (CB-Spider and CB-Tumblebug respond "not exist" errors with 500 status)
+func reasonOf(status int, message string) Reason {
+
+	msg := strings.ToLower(message)
+	switch {
+	case status == http.StatusNotFound:
+		return REASON_NOT_FOUND
+	case status == http.StatusConflict:
+		return REASON_CONFLICT
+	case status == http.StatusBadRequest || status == http.StatusUnprocessableEntity:
+		return REASON_VALIDATION
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return REASON_AUTH
+	case strings.Contains(msg, "not exist") || strings.Contains(msg, "not found"):
+		return REASON_NOT_FOUND
+	case strings.Contains(msg, "already exist"):
+		return REASON_CONFLICT
+	case status >= 500:
+		return REASON_SERVER
+	}
+	return REASON_UNKNOWN
+}
+
+// returns the reason of an error
+func ReasonOf(err error) Reason {
+	var e *APIError
+	if errors.As(err, &e) {
+		return e.Reason
+	}
+	return REASON_UNKNOWN
+}
+
+func IsNotFound(err error) bool {
+	return ReasonOf(err) == REASON_NOT_FOUND
+}
+
+func IsConflict(err error) bool {
+	return ReasonOf(err) == REASON_CONFLICT
+}
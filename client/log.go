@@ -0,0 +1,94 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// log level (-v)
+var Verbosity int
+
+// log levels
+const (
+	LOG_RETRY   = 1 // retries of requests
+	LOG_REQUEST = 6 // methods, urls, status codes and latencies of requests
+	LOG_HEADER  = 7 // headers of requests and responses
+	LOG_BODY    = 8 // bodies of requests and responses
+)
+
+// writes a log to stderr if the verbosity is greater than or equal to the level
+func logf(level int, format string, params ...interface{}) {
+	if Verbosity >= level {
+		fmt.Fprintf(os.Stderr, "%s %s\n", time.Now().Format("15:04:05.000"), fmt.Sprintf(format, params...))
+	}
+}
+
+// a logger of resty (errors are returned and retries are logged by clients)
+type restyLogger struct{}
+
+func (l *restyLogger) Errorf(format string, v ...interface{}) {}
+func (l *restyLogger) Warnf(format string, v ...interface{})  {}
+func (l *restyLogger) Debugf(format string, v ...interface{}) {}
+
+// logs a request and a response (secret headers and fields are redacted)
+func (c *Client) trace(method string, url string, body interface{}, resp *resty.Response, err error, latency time.Duration) {
+
+	if Verbosity < LOG_REQUEST {
+		return
+	}
+	if err != nil && (resp == nil || resp.RawResponse == nil) {
+		logf(LOG_REQUEST, "%s: %s %s failed in %d milliseconds (cause=%v)", c.Service, method, url, latency.Milliseconds(), err)
+	} else {
+		logf(LOG_REQUEST, "%s: %s %s %s in %d milliseconds", c.Service, method, url, resp.Status(), latency.Milliseconds())
+	}
+
+	// headers
+	if resp != nil && resp.Request != nil && resp.Request.RawRequest != nil {
+		logf(LOG_HEADER, "%s: request headers: %s", c.Service, c.redactHeader(resp.Request.RawRequest.Header))
+	}
+	if resp != nil && resp.RawResponse != nil {
+		logf(LOG_HEADER, "%s: response headers: %s", c.Service, c.redactHeader(resp.RawResponse.Header))
+	}
+
+	// bodies
+	if Verbosity < LOG_BODY {
+		return
+	}
+	if body != nil {
+		b, _ := json.Marshal(Redact(body))
+		logf(LOG_BODY, "%s: request body: %s", c.Service, string(b))
+	}
+	if resp != nil && len(resp.Body()) > 0 {
+		b, _ := json.Marshal(Redact(resp.Body()))
+		logf(LOG_BODY, "%s: response body: %s", c.Service, string(b))
+	}
+}
+
+// returns "key=value" pairs of headers (credentials, cookies and custom headers of the context are redacted)
+func (c *Client) redactHeader(header http.Header) string {
+
+	secrets := map[string]bool{"Cookie": true, "Set-Cookie": true}
+	if auth := c.Context.GetAuth(c.Service); auth != nil {
+		for k := range auth.Headers {
+			secrets[http.CanonicalHeaderKey(k)] = true
+		}
+	}
+
+	pairs := []string{}
+	for k, v := range header {
+		value := strings.Join(v, ",")
+		if IsSecretKey(k) || secrets[http.CanonicalHeaderKey(k)] {
+			value = REDACTED
+		}
+		pairs = append(pairs, fmt.Sprintf("%s=%s", k, value))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, " ")
+}
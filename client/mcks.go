@@ -0,0 +1,127 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/itnpeople/cbctl/app"
+)
+
+const (
+	ROLE_CONTROL_PLANE = "control-plane"
+	ROLE_WORKER        = "worker"
+)
+
+// a MCKS(Multi Cloud Kubernetes Service) client
+type MCKSClient struct {
+	*Client
+}
+
+type Status struct {
+	Kind    string `json:"kind"`
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type Cluster struct {
+	Raw
+	Kind            string `json:"kind"`
+	Name            string `json:"name"`
+	Status          string `json:"status"`
+	ClusterConfig   string `json:"clusterConfig"`
+	CpLeader        string `json:"cpLeader"`
+	NetworkCni      string `json:"networkCni"`
+	Label           string `json:"label"`
+	InstallMonAgent string `json:"installMonAgent"`
+	Description     string `json:"description"`
+	CreatedTime     string `json:"createdTime"`
+	Nodes           []Node `json:"nodes"`
+	Config          struct {
+		Kubernetes KubernetesConfig `json:"kubernetes"`
+	} `json:"config"`
+}
+
+// kubernetes settings of a cluster (empty if a server doesn't return)
+type KubernetesConfig struct {
+	Version          string `json:"version,omitempty"`
+	NetworkCni       string `json:"networkCni,omitempty"`
+	PodCidr          string `json:"podCidr,omitempty"`
+	ServiceCidr      string `json:"serviceCidr,omitempty"`
+	ServiceDnsDomain string `json:"serviceDnsDomain,omitempty"`
+}
+
+type ClusterList struct {
+	Raw
+	Kind  string    `json:"kind"`
+	Items []Cluster `json:"items"`
+}
+
+type Node struct {
+	Raw
+	Kind        string `json:"kind"`
+	Name        string `json:"name"`
+	Credential  string `json:"credential"`
+	PublicIP    string `json:"publicIp"`
+	UID         string `json:"uid"`
+	Role        string `json:"role"`
+	Spec        string `json:"spec"`
+	Connection  string `json:"connection,omitempty"`
+	Status      string `json:"status,omitempty"`
+	Csp         string `json:"csp"`
+	CreatedTime string `json:"createdTime"`
+	CspLabel    string `json:"cspLabel"`
+	RegionLabel string `json:"regionLabel"`
+	ZoneLabel   string `json:"zoneLabel"`
+}
+
+type NodeList struct {
+	Raw
+	Kind  string `json:"kind"`
+	Items []Node `json:"items"`
+}
+
+// returns a MCKS client of the context
+func NewMCKS(ctx *app.ConfigContext) *MCKSClient {
+	return &MCKSClient{Client: newClient(ctx, SERVICE_MCKS, ctx.Urls.MCKS)}
+}
+
+func (c *MCKSClient) ListClusters(namespace string) (*ClusterList, error) {
+	res := &ClusterList{}
+	return res, c.get(fmt.Sprintf("/ns/%s/clusters", namespace), res)
+}
+
+func (c *MCKSClient) GetCluster(namespace string, name string) (*Cluster, error) {
+	res := &Cluster{}
+	return res, c.get(fmt.Sprintf("/ns/%s/clusters/%s", namespace, name), res)
+}
+
+// creates a cluster (body : a request struct or JSON bytes)
+func (c *MCKSClient) CreateCluster(namespace string, body interface{}) (*Cluster, error) {
+	res := &Cluster{}
+	return res, c.post(fmt.Sprintf("/ns/%s/clusters", namespace), body, res)
+}
+
+func (c *MCKSClient) DeleteCluster(namespace string, name string) (*Status, error) {
+	res := &Status{}
+	return res, c.delete(fmt.Sprintf("/ns/%s/clusters/%s", namespace, name), res)
+}
+
+func (c *MCKSClient) ListNodes(namespace string, cluster string) (*NodeList, error) {
+	res := &NodeList{}
+	return res, c.get(fmt.Sprintf("/ns/%s/clusters/%s/nodes", namespace, cluster), res)
+}
+
+func (c *MCKSClient) GetNode(namespace string, cluster string, name string) (*Node, error) {
+	res := &Node{}
+	return res, c.get(fmt.Sprintf("/ns/%s/clusters/%s/nodes/%s", namespace, cluster, name), res)
+}
+
+// adds nodes to a cluster (body : a request struct or JSON bytes)
+func (c *MCKSClient) CreateNode(namespace string, cluster string, body interface{}) (*NodeList, error) {
+	res := &NodeList{}
+	return res, c.post(fmt.Sprintf("/ns/%s/clusters/%s/nodes", namespace, cluster), body, res)
+}
+
+func (c *MCKSClient) DeleteNode(namespace string, cluster string, name string) (*Status, error) {
+	res := &Status{}
+	return res, c.delete(fmt.Sprintf("/ns/%s/clusters/%s/nodes/%s", namespace, cluster, name), res)
+}
@@ -0,0 +1,224 @@
+package client
+
+// implements app.NamedObject, app.ListObject (-o name)
+
+// cluster
+func (obj *Cluster) GetKind() string {
+	return "cluster"
+}
+
+func (obj *Cluster) GetName() string {
+	return obj.Name
+}
+
+func (obj *ClusterList) GetItems() []interface{} {
+	items := []interface{}{}
+	for i := range obj.Items {
+		items = append(items, &obj.Items[i])
+	}
+	return items
+}
+
+// node
+func (obj *Node) GetKind() string {
+	return "node"
+}
+
+func (obj *Node) GetName() string {
+	return obj.Name
+}
+
+func (obj *NodeList) GetItems() []interface{} {
+	items := []interface{}{}
+	for i := range obj.Items {
+		items = append(items, &obj.Items[i])
+	}
+	return items
+}
+
+// driver
+func (obj *Driver) GetKind() string {
+	return "driver"
+}
+
+func (obj *Driver) GetName() string {
+	return obj.DriverName
+}
+
+func (obj *DriverList) GetItems() []interface{} {
+	items := []interface{}{}
+	for i := range obj.Items {
+		items = append(items, &obj.Items[i])
+	}
+	return items
+}
+
+// credential
+func (obj *Credential) GetKind() string {
+	return "credential"
+}
+
+func (obj *Credential) GetName() string {
+	return obj.CredentialName
+}
+
+func (obj *CredentialList) GetItems() []interface{} {
+	items := []interface{}{}
+	for i := range obj.Items {
+		items = append(items, &obj.Items[i])
+	}
+	return items
+}
+
+// region
+func (obj *Region) GetKind() string {
+	return "region"
+}
+
+func (obj *Region) GetName() string {
+	return obj.RegionName
+}
+
+func (obj *RegionList) GetItems() []interface{} {
+	items := []interface{}{}
+	for i := range obj.Items {
+		items = append(items, &obj.Items[i])
+	}
+	return items
+}
+
+// connection
+func (obj *Connection) GetKind() string {
+	return "connection"
+}
+
+func (obj *Connection) GetName() string {
+	return obj.ConfigName
+}
+
+func (obj *ConnectionList) GetItems() []interface{} {
+	items := []interface{}{}
+	for i := range obj.Items {
+		items = append(items, &obj.Items[i])
+	}
+	return items
+}
+
+// namespace
+func (obj *Namespace) GetKind() string {
+	return "namespace"
+}
+
+func (obj *Namespace) GetName() string {
+	return obj.Id
+}
+
+func (obj *NamespaceList) GetItems() []interface{} {
+	items := []interface{}{}
+	for i := range obj.Items {
+		items = append(items, &obj.Items[i])
+	}
+	return items
+}
+
+// vpc
+func (obj *VNet) GetKind() string {
+	return "vpc"
+}
+
+func (obj *VNet) GetName() string {
+	return obj.Id
+}
+
+func (obj *VNetList) GetItems() []interface{} {
+	items := []interface{}{}
+	for i := range obj.Items {
+		items = append(items, &obj.Items[i])
+	}
+	return items
+}
+
+// sg
+func (obj *SecurityGroup) GetKind() string {
+	return "sg"
+}
+
+func (obj *SecurityGroup) GetName() string {
+	return obj.Id
+}
+
+func (obj *SecurityGroupList) GetItems() []interface{} {
+	items := []interface{}{}
+	for i := range obj.Items {
+		items = append(items, &obj.Items[i])
+	}
+	return items
+}
+
+// sshkey
+func (obj *SshKey) GetKind() string {
+	return "sshkey"
+}
+
+func (obj *SshKey) GetName() string {
+	return obj.Id
+}
+
+func (obj *SshKeyList) GetItems() []interface{} {
+	items := []interface{}{}
+	for i := range obj.Items {
+		items = append(items, &obj.Items[i])
+	}
+	return items
+}
+
+// image
+func (obj *Image) GetKind() string {
+	return "image"
+}
+
+func (obj *Image) GetName() string {
+	return obj.Id
+}
+
+func (obj *ImageList) GetItems() []interface{} {
+	items := []interface{}{}
+	for i := range obj.Items {
+		items = append(items, &obj.Items[i])
+	}
+	return items
+}
+
+// spec
+func (obj *Spec) GetKind() string {
+	return "spec"
+}
+
+func (obj *Spec) GetName() string {
+	return obj.Id
+}
+
+func (obj *SpecList) GetItems() []interface{} {
+	items := []interface{}{}
+	for i := range obj.Items {
+		items = append(items, &obj.Items[i])
+	}
+	return items
+}
+
+// mcis
+func (obj *MCIS) GetKind() string {
+	return "mcis"
+}
+
+func (obj *MCIS) GetName() string {
+	return obj.Id
+}
+
+func (obj *MCISList) GetItems() []interface{} {
+	items := []interface{}{}
+	for i := range obj.Items {
+		items = append(items, &obj.Items[i])
+	}
+	return items
+}
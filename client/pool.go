@@ -0,0 +1,110 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// a location of a connection (a CSP, a region and a zone of the region of the connection)
+type Location struct {
+	Csp    string `json:"csp"`
+	Region string `json:"region"`
+	Zone   string `json:"zone,omitempty"`
+}
+
+func (l Location) String() string {
+	return fmt.Sprintf("%s/%s/%s", l.Csp, l.Region, l.Zone)
+}
+
+// whether a node is located at the location (compares a CSP, a region and a zone labels of the node, empty values never match)
+func (l Location) Contains(node Node) bool {
+	if l.Csp == "" || l.Region == "" || node.Csp == "" || node.RegionLabel == "" {
+		return false
+	}
+	if !strings.EqualFold(l.Csp, node.Csp) || !strings.EqualFold(l.Region, node.RegionLabel) {
+		return false
+	}
+	// regions without a zone (eg. azure) are compared by a region only
+	return l.Zone == "" || strings.EqualFold(l.Zone, node.ZoneLabel)
+}
+
+// returns a location of a region ("Region" or "location" and "Zone" keys)
+func (r *Region) Location() Location {
+	l := Location{Csp: strings.ToLower(r.ProviderName)}
+	for _, kv := range r.KeyValueInfoList {
+		switch strings.ToLower(kv.Key) {
+		case "region", "location":
+			if kv.Value != "" {
+				l.Region = kv.Value
+			}
+		case "zone":
+			l.Zone = kv.Value
+		}
+	}
+	return l
+}
+
+// returns locations of all connections (by a connection name)
+func (c *SpiderClient) ConnectionLocations() (map[string]Location, error) {
+
+	connections, err := c.ListConnections()
+	if err != nil {
+		return nil, err
+	}
+	regions, err := c.ListRegions()
+	if err != nil {
+		return nil, err
+	}
+	byName := map[string]Location{}
+	for i := range regions.Items {
+		byName[regions.Items[i].RegionName] = regions.Items[i].Location()
+	}
+	locations := map[string]Location{}
+	for _, conn := range connections.Items {
+		if l, ok := byName[conn.RegionName]; ok {
+			l.Csp = strings.ToLower(conn.ProviderName)
+			locations[conn.ConfigName] = l
+		}
+	}
+	return locations, nil
+}
+
+// returns a connection name of a node (a connection of the node or a single connection located at the node)
+func ResolveConnection(node Node, locations map[string]Location) (string, error) {
+	if node.Connection != "" {
+		return node.Connection, nil
+	}
+	candidates := []string{}
+	for name, l := range locations {
+		if l.Contains(node) {
+			candidates = append(candidates, name)
+		}
+	}
+	sort.Strings(candidates)
+	switch len(candidates) {
+	case 1:
+		return candidates[0], nil
+	case 0:
+		return "", fmt.Errorf("Unable to identify a connection of node/%s (csp=%s, region=%s, zone=%s)", node.Name, node.Csp, node.RegionLabel, node.ZoneLabel)
+	}
+	return "", fmt.Errorf("Unable to identify a connection of node/%s, connections are located at the same region (csp=%s, region=%s, zone=%s, connections=%s)", node.Name, node.Csp, node.RegionLabel, node.ZoneLabel, strings.Join(candidates, ","))
+}
+
+// returns nodes of a node-pool (a role, a connection and a spec), fails if a connection of a node of the role and the spec is not identified
+func PoolNodes(nodes []Node, role string, connection string, spec string, locations map[string]Location) ([]Node, error) {
+	pool := []Node{}
+	for _, node := range nodes {
+		if node.Role != role || node.Spec != spec {
+			continue
+		}
+		name, err := ResolveConnection(node, locations)
+		if err != nil {
+			return nil, err
+		}
+		if name == connection {
+			pool = append(pool, node)
+		}
+	}
+	return pool, nil
+}
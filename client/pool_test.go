@@ -0,0 +1,108 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+)
+
+var testLocations = map[string]Location{
+	"config-aws-tokyo":   {Csp: "aws", Region: "ap-northeast-1", Zone: "ap-northeast-1a"},
+	"config-gcp-tokyo":   {Csp: "gcp", Region: "asia-northeast1", Zone: "asia-northeast1-a"},
+	"config-azure-tokyo": {Csp: "azure", Region: "japaneast"},
+}
+
+func TestRegionLocation(t *testing.T) {
+	tests := []struct {
+		region Region
+		want   Location
+	}{
+		{Region{ProviderName: "AWS", KeyValueInfoList: []KeyValue{{"Region", "ap-northeast-1"}, {"Zone", "ap-northeast-1a"}, {"location", ""}}}, Location{"aws", "ap-northeast-1", "ap-northeast-1a"}},
+		{Region{ProviderName: "AZURE", KeyValueInfoList: []KeyValue{{"Region", ""}, {"Zone", ""}, {"location", "japaneast"}, {"ResourceGroup", "rg"}}}, Location{"azure", "japaneast", ""}},
+		{Region{ProviderName: "GCP"}, Location{"gcp", "", ""}},
+	}
+	for _, tt := range tests {
+		if got := tt.region.Location(); got != tt.want {
+			t.Errorf("Location() = %v, want %v", got, tt.want)
+		}
+	}
+}
+
+func TestResolveConnection(t *testing.T) {
+	tests := []struct {
+		name    string
+		node    Node
+		want    string
+		wantErr bool
+	}{
+		{"connection of a node", Node{Name: "n", Connection: "config-x"}, "config-x", false},
+		{"by a zone", Node{Name: "n", Csp: "aws", RegionLabel: "ap-northeast-1", ZoneLabel: "ap-northeast-1a"}, "config-aws-tokyo", false},
+		{"case-insensitive csp", Node{Name: "n", Csp: "GCP", RegionLabel: "asia-northeast1", ZoneLabel: "asia-northeast1-a"}, "config-gcp-tokyo", false},
+		{"a region without a zone", Node{Name: "n", Csp: "azure", RegionLabel: "japaneast", ZoneLabel: "1"}, "config-azure-tokyo", false},
+		{"another zone", Node{Name: "n", Csp: "aws", RegionLabel: "ap-northeast-1", ZoneLabel: "ap-northeast-1c"}, "", true},
+		{"no labels", Node{Name: "n"}, "", true},
+		{"no region label", Node{Name: "n", Csp: "azure"}, "", true},
+	}
+	for _, tt := range tests {
+		got, err := ResolveConnection(tt.node, testLocations)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("%s: ResolveConnection() = %q, %v, want %q (error=%v)", tt.name, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestResolveConnectionAmbiguous(t *testing.T) {
+	locations := map[string]Location{
+		"config-aws-tokyo":   {Csp: "aws", Region: "ap-northeast-1", Zone: "ap-northeast-1a"},
+		"config-aws-tokyo-2": {Csp: "aws", Region: "ap-northeast-1", Zone: "ap-northeast-1a"},
+	}
+	if _, err := ResolveConnection(Node{Name: "n", Csp: "aws", RegionLabel: "ap-northeast-1", ZoneLabel: "ap-northeast-1a"}, locations); err == nil {
+		t.Error("ResolveConnection() of connections at the same location expected an error")
+	}
+}
+
+func TestPoolNodes(t *testing.T) {
+	nodes := []Node{
+		{Name: "cp", Role: ROLE_CONTROL_PLANE, Spec: "t2.medium", Csp: "aws", RegionLabel: "ap-northeast-1", ZoneLabel: "ap-northeast-1a"},
+		{Name: "w1", Role: ROLE_WORKER, Spec: "t2.medium", Csp: "aws", RegionLabel: "ap-northeast-1", ZoneLabel: "ap-northeast-1a"},
+		{Name: "w2", Role: ROLE_WORKER, Spec: "t2.medium", Csp: "aws", RegionLabel: "ap-northeast-1", ZoneLabel: "ap-northeast-1a"},
+		{Name: "w3", Role: ROLE_WORKER, Spec: "e2-medium", Csp: "gcp", RegionLabel: "asia-northeast1", ZoneLabel: "asia-northeast1-a"},
+	}
+	names := func(nodes []Node) []string {
+		s := []string{}
+		for _, n := range nodes {
+			s = append(s, n.Name)
+		}
+		return s
+	}
+
+	tests := []struct {
+		connection string
+		spec       string
+		want       []string
+	}{
+		{"config-aws-tokyo", "t2.medium", []string{"w1", "w2"}},
+		{"config-gcp-tokyo", "t2.medium", []string{}}, // a spec of another cloud never matches
+		{"config-gcp-tokyo", "e2-medium", []string{"w3"}},
+		{"config-azure-tokyo", "e2-medium", []string{}},
+	}
+	for _, tt := range tests {
+		pool, err := PoolNodes(nodes, ROLE_WORKER, tt.connection, tt.spec, testLocations)
+		if err != nil {
+			t.Errorf("PoolNodes(%s, %s) : %v", tt.connection, tt.spec, err)
+			continue
+		}
+		if got := names(pool); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("PoolNodes(%s, %s) = %v, want %v", tt.connection, tt.spec, got, tt.want)
+		}
+	}
+
+	// a node without labels of a spec is not identified
+	unknown := append(nodes, Node{Name: "w4", Role: ROLE_WORKER, Spec: "t2.medium"})
+	if _, err := PoolNodes(unknown, ROLE_WORKER, "config-gcp-tokyo", "t2.medium", testLocations); err == nil {
+		t.Error("PoolNodes() with an unidentified node expected an error")
+	}
+	// nodes of other specs are not resolved
+	if _, err := PoolNodes(unknown, ROLE_WORKER, "config-gcp-tokyo", "e2-medium", testLocations); err != nil {
+		t.Errorf("PoolNodes() of another spec : %v", err)
+	}
+}
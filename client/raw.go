@@ -0,0 +1,20 @@
+package client
+
+// a raw response body of a result (written as is by "-o yaml" and "-o json", typed fields are used by tables and names)
+type Raw struct {
+	body []byte
+}
+
+// a result keeps a raw response body
+type rawResult interface {
+	setRawBody(body []byte)
+}
+
+func (r *Raw) setRawBody(body []byte) {
+	r.body = body
+}
+
+// returns a raw response body (nil if the object is not a response)
+func (r *Raw) RawBody() []byte {
+	return r.body
+}
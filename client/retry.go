@@ -0,0 +1,112 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// defaults of requests
+const (
+	DEFAULT_RETRY_BACKOFF   = time.Second
+	MAX_RETRY_BACKOFF       = 30 * time.Second
+	DEFAULT_REQUEST_TIMEOUT = 0 // no timeout (creating a cluster takes long)
+)
+
+// request settings overridden by flags (nil : a default of the context)
+var Overrides struct {
+	Timeout      *time.Duration
+	Retries      *int
+	RetryBackoff *time.Duration
+}
+
+// applies a timeout and retries (defaults of the context are overridden by flags)
+func (c *Client) setRequest() error {
+
+	timeout, retries, backoff := time.Duration(DEFAULT_REQUEST_TIMEOUT), 0, DEFAULT_RETRY_BACKOFF
+	if conf := c.Context.Request; conf != nil {
+		var err error
+		if conf.Timeout != "" {
+			if timeout, err = time.ParseDuration(conf.Timeout); err != nil {
+				return fmt.Errorf("Invalid request timeout (timeout=%s)", conf.Timeout)
+			}
+		}
+		if conf.RetryBackoff != "" {
+			if backoff, err = time.ParseDuration(conf.RetryBackoff); err != nil {
+				return fmt.Errorf("Invalid retry backoff (retry-backoff=%s)", conf.RetryBackoff)
+			}
+		}
+		retries = conf.Retries
+	}
+	if Overrides.Timeout != nil {
+		timeout = *Overrides.Timeout
+	}
+	if Overrides.Retries != nil {
+		retries = *Overrides.Retries
+	}
+	if Overrides.RetryBackoff != nil {
+		backoff = *Overrides.RetryBackoff
+	}
+	if timeout < 0 || retries < 0 || backoff < 0 {
+		return fmt.Errorf("Invalid request settings (timeout=%v, retries=%d, retry-backoff=%v)", timeout, retries, backoff)
+	}
+
+	c.http.SetTimeout(timeout)
+	if retries == 0 {
+		return nil
+	}
+	max := MAX_RETRY_BACKOFF
+	if backoff > max {
+		max = backoff
+	}
+	c.http.SetRetryCount(retries).
+		SetRetryWaitTime(backoff).
+		SetRetryMaxWaitTime(max).
+		AddRetryCondition(retryable).
+		AddRetryHook(func(resp *resty.Response, err error) {
+			method, url, attempt := "", c.Url, 0
+			if resp != nil && resp.Request != nil {
+				method, url, attempt = resp.Request.Method, resp.Request.URL, resp.Request.Attempt
+			}
+			if attempt > retries {
+				return // the last attempt
+			}
+			cause := fmt.Sprint(err)
+			if err == nil && resp != nil {
+				cause = resp.Status()
+			}
+			logf(LOG_RETRY, "%s: retrying %s %s (attempt=%d/%d, cause=%s)", c.Service, method, url, attempt, retries, cause)
+		})
+	return nil
+}
+
+// retries idempotent requests (GET, DELETE) on connection errors, timeouts and temporary server errors
+// and other requests only if they could not be sent (connection refused)
+func retryable(resp *resty.Response, err error) bool {
+
+	method := ""
+	if resp != nil && resp.Request != nil {
+		method = resp.Request.Method
+	}
+	idempotent := (method == resty.MethodGet || method == resty.MethodDelete)
+
+	if err != nil {
+		var opErr *net.OpError
+		if errors.As(err, &opErr) && opErr.Op == "dial" {
+			return true
+		}
+		return idempotent
+	}
+	if resp == nil || !idempotent {
+		return false
+	}
+	switch resp.StatusCode() {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout, http.StatusTooManyRequests:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+package client
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+const REDACTED = "******"
+
+// secret keys of spider credentials (all providers)
+var CREDENTIAL_SECRET_KEYS = []string{"ClientSecret", "PrivateKey", "Password", "ApiKey", "AuthToken"}
+
+// keys of secret values (lower-case)
+//   - spider credentials : CREDENTIAL_SECRET_KEYS
+//   - mcks : clusterConfig (kubeconfig), credential (ssh private-key of a node)
+//   - headers : authorization
+var secretKeys = func() map[string]bool {
+	keys := map[string]bool{"clusterconfig": true, "credential": true, "authorization": true}
+	for _, k := range CREDENTIAL_SECRET_KEYS {
+		keys[strings.ToLower(k)] = true
+	}
+	return keys
+}()
+
+// whether a key (a field name, a header name or a key of a key-value) is a secret
+func IsSecretKey(key string) bool {
+	return secretKeys[strings.ToLower(key)]
+}
+
+// returns a generic copy of a value (a struct, a map or json bytes) with secret values masked
+func Redact(v interface{}) interface{} {
+
+	var data interface{}
+	switch b := v.(type) {
+	case nil:
+		return nil
+	case []byte:
+		if err := json.Unmarshal(b, &data); err != nil {
+			return string(b)
+		}
+	default:
+		if b, err := json.Marshal(v); err != nil {
+			return nil
+		} else if err := json.Unmarshal(b, &data); err != nil {
+			return nil
+		}
+	}
+	return redact(data)
+}
+
+// masks string values of secret keys (includes key-value lists eg. {"Key":"ClientSecret","Value":"..."})
+func redact(data interface{}) interface{} {
+
+	switch v := data.(type) {
+	case map[string]interface{}:
+		key, value := "", ""
+		for k, e := range v {
+			if s, ok := e.(string); ok && IsSecretKey(k) {
+				if s != "" {
+					v[k] = REDACTED
+				}
+			} else {
+				v[k] = redact(e)
+			}
+			if strings.EqualFold(k, "key") {
+				key, _ = e.(string)
+			} else if strings.EqualFold(k, "value") {
+				value = k
+			}
+		}
+		if value != "" && IsSecretKey(key) {
+			if s, ok := v[value].(string); ok && s != "" {
+				v[value] = REDACTED
+			}
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = redact(e)
+		}
+	}
+	return data
+}
+
+// returns a copy of a credential with secret values masked
+func (obj *Credential) Masked() *Credential {
+	masked := *obj
+	masked.KeyValueInfoList = make([]KeyValue, len(obj.KeyValueInfoList))
+	for i, kv := range obj.KeyValueInfoList {
+		if kv.Value != "" && IsSecretKey(kv.Key) {
+			kv.Value = REDACTED
+		}
+		masked.KeyValueInfoList[i] = kv
+	}
+	return &masked
+}
+
+// returns a copy of credentials with secret values masked
+func (obj *CredentialList) Masked() *CredentialList {
+	masked := &CredentialList{Items: make([]Credential, len(obj.Items))}
+	for i := range obj.Items {
+		masked.Items[i] = *obj.Items[i].Masked()
+	}
+	return masked
+}
@@ -0,0 +1,160 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/itnpeople/cbctl/app"
+)
+
+// a CB-Spider client
+type SpiderClient struct {
+	*Client
+}
+
+type KeyValue struct {
+	Key   string `json:"Key"`
+	Value string `json:"Value"`
+}
+
+type BooleanInfo struct {
+	Result string `json:"Result"`
+}
+
+type Driver struct {
+	Raw
+	DriverName        string `json:"DriverName"`
+	ProviderName      string `json:"ProviderName"`
+	DriverLibFileName string `json:"DriverLibFileName"`
+}
+
+type DriverList struct {
+	Raw
+	Items []Driver `json:"driver"`
+}
+
+type Credential struct {
+	CredentialName   string     `json:"CredentialName"`
+	ProviderName     string     `json:"ProviderName"`
+	KeyValueInfoList []KeyValue `json:"KeyValueInfoList"`
+}
+
+type CredentialList struct {
+	Items []Credential `json:"credential"`
+}
+
+type Region struct {
+	Raw
+	RegionName       string     `json:"RegionName"`
+	ProviderName     string     `json:"ProviderName"`
+	KeyValueInfoList []KeyValue `json:"KeyValueInfoList"`
+}
+
+type RegionList struct {
+	Raw
+	Items []Region `json:"region"`
+}
+
+type Connection struct {
+	Raw
+	ConfigName     string `json:"ConfigName"`
+	ProviderName   string `json:"ProviderName"`
+	DriverName     string `json:"DriverName"`
+	CredentialName string `json:"CredentialName"`
+	RegionName     string `json:"RegionName"`
+}
+
+type ConnectionList struct {
+	Raw
+	Items []Connection `json:"connectionconfig"`
+}
+
+// returns a CB-Spider client of the context
+func NewSpider(ctx *app.ConfigContext) *SpiderClient {
+	return &SpiderClient{Client: newClient(ctx, SERVICE_SPIDER, ctx.Urls.Spider)}
+}
+
+// returns a cloud driver name of the CSP
+func DriverName(csp string) string {
+	return fmt.Sprintf("%s-driver-v1.0", strings.ToLower(csp))
+}
+
+func (c *SpiderClient) ListDrivers() (*DriverList, error) {
+	res := &DriverList{}
+	return res, c.get("/driver", res)
+}
+
+func (c *SpiderClient) GetDriver(name string) (*Driver, error) {
+	res := &Driver{}
+	return res, c.get("/driver/"+name, res)
+}
+
+func (c *SpiderClient) CreateDriver(body interface{}) (*Driver, error) {
+	res := &Driver{}
+	return res, c.post("/driver", body, res)
+}
+
+func (c *SpiderClient) DeleteDriver(name string) (*BooleanInfo, error) {
+	res := &BooleanInfo{}
+	return res, c.delete("/driver/"+name, res)
+}
+
+func (c *SpiderClient) ListCredentials() (*CredentialList, error) {
+	res := &CredentialList{}
+	return res, c.get("/credential", res)
+}
+
+func (c *SpiderClient) GetCredential(name string) (*Credential, error) {
+	res := &Credential{}
+	return res, c.get("/credential/"+name, res)
+}
+
+func (c *SpiderClient) CreateCredential(body interface{}) (*Credential, error) {
+	res := &Credential{}
+	return res, c.post("/credential", body, res)
+}
+
+func (c *SpiderClient) DeleteCredential(name string) (*BooleanInfo, error) {
+	res := &BooleanInfo{}
+	return res, c.delete("/credential/"+name, res)
+}
+
+func (c *SpiderClient) ListRegions() (*RegionList, error) {
+	res := &RegionList{}
+	return res, c.get("/region", res)
+}
+
+func (c *SpiderClient) GetRegion(name string) (*Region, error) {
+	res := &Region{}
+	return res, c.get("/region/"+name, res)
+}
+
+func (c *SpiderClient) CreateRegion(body interface{}) (*Region, error) {
+	res := &Region{}
+	return res, c.post("/region", body, res)
+}
+
+func (c *SpiderClient) DeleteRegion(name string) (*BooleanInfo, error) {
+	res := &BooleanInfo{}
+	return res, c.delete("/region/"+name, res)
+}
+
+func (c *SpiderClient) ListConnections() (*ConnectionList, error) {
+	res := &ConnectionList{}
+	return res, c.get("/connectionconfig", res)
+}
+
+func (c *SpiderClient) GetConnection(name string) (*Connection, error) {
+	res := &Connection{}
+	return res, c.get("/connectionconfig/"+name, res)
+}
+
+func (c *SpiderClient) CreateConnection(body interface{}) (*Connection, error) {
+	res := &Connection{}
+	return res, c.post("/connectionconfig", body, res)
+}
+
+func (c *SpiderClient) DeleteConnection(name string) (*BooleanInfo, error) {
+	res := &BooleanInfo{}
+	return res, c.delete("/connectionconfig/"+name, res)
+}
@@ -0,0 +1,398 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/itnpeople/cbctl/app"
+)
+
+// implements app.TableObject (-o table, -o wide)
+
+// returns a value of the key (case-insensitive)
+func valueOf(list []KeyValue, key string) string {
+	for _, kv := range list {
+		if strings.EqualFold(kv.Key, key) {
+			return kv.Value
+		}
+	}
+	return ""
+}
+
+// cluster
+func (obj *Cluster) Columns(wide bool) []string {
+	if wide {
+		return []string{"NAME", "STATUS", "CONTROL-PLANE", "WORKERS", "AGE", "NETWORK-CNI", "CP-LEADER", "LABEL"}
+	}
+	return []string{"NAME", "STATUS", "CONTROL-PLANE", "WORKERS", "AGE"}
+}
+
+func (obj *Cluster) Rows(wide bool) [][]string {
+	cp, w := 0, 0
+	for _, node := range obj.Nodes {
+		if node.Role == ROLE_CONTROL_PLANE {
+			cp++
+		} else {
+			w++
+		}
+	}
+	row := []string{obj.Name, app.None(obj.Status), fmt.Sprint(cp), fmt.Sprint(w), app.Age(obj.CreatedTime)}
+	if wide {
+		row = append(row, app.None(obj.NetworkCni), app.None(obj.CpLeader), app.None(obj.Label))
+	}
+	return [][]string{row}
+}
+
+func (obj *ClusterList) Columns(wide bool) []string {
+	return (&Cluster{}).Columns(wide)
+}
+
+func (obj *ClusterList) Rows(wide bool) [][]string {
+	rows := [][]string{}
+	for i := range obj.Items {
+		rows = append(rows, obj.Items[i].Rows(wide)...)
+	}
+	return rows
+}
+
+// node
+func (obj *Node) Columns(wide bool) []string {
+	if wide {
+		return []string{"NAME", "ROLE", "CONNECTION", "SPEC", "PUBLIC-IP", "STATUS", "AGE", "CSP", "REGION", "ZONE", "UID"}
+	}
+	return []string{"NAME", "ROLE", "CONNECTION", "SPEC", "PUBLIC-IP", "STATUS", "AGE"}
+}
+
+func (obj *Node) Rows(wide bool) [][]string {
+	row := []string{obj.Name, app.None(obj.Role), app.None(obj.Connection), app.None(obj.Spec), app.None(obj.PublicIP), app.None(obj.Status), app.Age(obj.CreatedTime)}
+	if wide {
+		row = append(row, app.None(obj.Csp), app.None(obj.RegionLabel), app.None(obj.ZoneLabel), app.None(obj.UID))
+	}
+	return [][]string{row}
+}
+
+func (obj *NodeList) Columns(wide bool) []string {
+	return (&Node{}).Columns(wide)
+}
+
+func (obj *NodeList) Rows(wide bool) [][]string {
+	rows := [][]string{}
+	for i := range obj.Items {
+		rows = append(rows, obj.Items[i].Rows(wide)...)
+	}
+	return rows
+}
+
+// driver
+func (obj *Driver) Columns(wide bool) []string {
+	return []string{"NAME", "PROVIDER", "LIBRARY"}
+}
+
+func (obj *Driver) Rows(wide bool) [][]string {
+	return [][]string{{obj.DriverName, obj.ProviderName, app.None(obj.DriverLibFileName)}}
+}
+
+func (obj *DriverList) Columns(wide bool) []string {
+	return (&Driver{}).Columns(wide)
+}
+
+func (obj *DriverList) Rows(wide bool) [][]string {
+	rows := [][]string{}
+	for i := range obj.Items {
+		rows = append(rows, obj.Items[i].Rows(wide)...)
+	}
+	return rows
+}
+
+// credential
+func (obj *Credential) Columns(wide bool) []string {
+	return []string{"NAME", "PROVIDER", "KEYS"}
+}
+
+func (obj *Credential) Rows(wide bool) [][]string {
+	keys := []string{}
+	for _, kv := range obj.KeyValueInfoList {
+		if kv.Value != "" {
+			keys = append(keys, kv.Key)
+		}
+	}
+	return [][]string{{obj.CredentialName, obj.ProviderName, app.None(strings.Join(keys, ","))}}
+}
+
+func (obj *CredentialList) Columns(wide bool) []string {
+	return (&Credential{}).Columns(wide)
+}
+
+func (obj *CredentialList) Rows(wide bool) [][]string {
+	rows := [][]string{}
+	for i := range obj.Items {
+		rows = append(rows, obj.Items[i].Rows(wide)...)
+	}
+	return rows
+}
+
+// region
+func (obj *Region) Columns(wide bool) []string {
+	if wide {
+		return []string{"NAME", "PROVIDER", "REGION", "ZONE", "LOCATION", "RESOURCE-GROUP"}
+	}
+	return []string{"NAME", "PROVIDER", "REGION", "ZONE"}
+}
+
+func (obj *Region) Rows(wide bool) [][]string {
+	row := []string{obj.RegionName, obj.ProviderName, app.None(valueOf(obj.KeyValueInfoList, "Region")), app.None(valueOf(obj.KeyValueInfoList, "Zone"))}
+	if wide {
+		row = append(row, app.None(valueOf(obj.KeyValueInfoList, "location")), app.None(valueOf(obj.KeyValueInfoList, "ResourceGroup")))
+	}
+	return [][]string{row}
+}
+
+func (obj *RegionList) Columns(wide bool) []string {
+	return (&Region{}).Columns(wide)
+}
+
+func (obj *RegionList) Rows(wide bool) [][]string {
+	rows := [][]string{}
+	for i := range obj.Items {
+		rows = append(rows, obj.Items[i].Rows(wide)...)
+	}
+	return rows
+}
+
+// connection
+func (obj *Connection) Columns(wide bool) []string {
+	return []string{"NAME", "PROVIDER", "DRIVER", "CREDENTIAL", "REGION"}
+}
+
+func (obj *Connection) Rows(wide bool) [][]string {
+	return [][]string{{obj.ConfigName, obj.ProviderName, obj.DriverName, obj.CredentialName, obj.RegionName}}
+}
+
+func (obj *ConnectionList) Columns(wide bool) []string {
+	return (&Connection{}).Columns(wide)
+}
+
+func (obj *ConnectionList) Rows(wide bool) [][]string {
+	rows := [][]string{}
+	for i := range obj.Items {
+		rows = append(rows, obj.Items[i].Rows(wide)...)
+	}
+	return rows
+}
+
+// namespace
+func (obj *Namespace) Columns(wide bool) []string {
+	return []string{"NAME", "DESCRIPTION"}
+}
+
+func (obj *Namespace) Rows(wide bool) [][]string {
+	return [][]string{{obj.Id, app.None(obj.Description)}}
+}
+
+func (obj *NamespaceList) Columns(wide bool) []string {
+	return (&Namespace{}).Columns(wide)
+}
+
+func (obj *NamespaceList) Rows(wide bool) [][]string {
+	rows := [][]string{}
+	for i := range obj.Items {
+		rows = append(rows, obj.Items[i].Rows(wide)...)
+	}
+	return rows
+}
+
+// vpc
+func (obj *VNet) Columns(wide bool) []string {
+	if wide {
+		return []string{"NAME", "CONNECTION", "CIDR", "STATUS", "SUBNETS", "CSP-ID"}
+	}
+	return []string{"NAME", "CONNECTION", "CIDR", "STATUS"}
+}
+
+func (obj *VNet) Rows(wide bool) [][]string {
+	row := []string{obj.Id, obj.ConnectionName, app.None(obj.CidrBlock), app.None(obj.Status)}
+	if wide {
+		subnets := []string{}
+		for _, s := range obj.SubnetInfoList {
+			subnets = append(subnets, s.IPv4_CIDR)
+		}
+		row = append(row, app.None(strings.Join(subnets, ",")), app.None(obj.CspVNetId))
+	}
+	return [][]string{row}
+}
+
+func (obj *VNetList) Columns(wide bool) []string {
+	return (&VNet{}).Columns(wide)
+}
+
+func (obj *VNetList) Rows(wide bool) [][]string {
+	rows := [][]string{}
+	for i := range obj.Items {
+		rows = append(rows, obj.Items[i].Rows(wide)...)
+	}
+	return rows
+}
+
+// security group
+func (obj *SecurityGroup) Columns(wide bool) []string {
+	if wide {
+		return []string{"NAME", "CONNECTION", "VPC", "RULES", "CSP-ID"}
+	}
+	return []string{"NAME", "CONNECTION", "VPC", "RULES"}
+}
+
+func (obj *SecurityGroup) Rows(wide bool) [][]string {
+	rules := []string{}
+	for _, r := range obj.FirewallRules {
+		rules = append(rules, fmt.Sprintf("%s/%s-%s", r.IPProtocol, r.FromPort, r.ToPort))
+	}
+	row := []string{obj.Id, obj.ConnectionName, app.None(obj.VNetId), app.None(strings.Join(rules, ","))}
+	if wide {
+		row = append(row, app.None(obj.CspSecurityGroupId))
+	}
+	return [][]string{row}
+}
+
+func (obj *SecurityGroupList) Columns(wide bool) []string {
+	return (&SecurityGroup{}).Columns(wide)
+}
+
+func (obj *SecurityGroupList) Rows(wide bool) [][]string {
+	rows := [][]string{}
+	for i := range obj.Items {
+		rows = append(rows, obj.Items[i].Rows(wide)...)
+	}
+	return rows
+}
+
+// ssh-key
+func (obj *SshKey) Columns(wide bool) []string {
+	if wide {
+		return []string{"NAME", "CONNECTION", "USERNAME", "CSP-NAME", "FINGERPRINT"}
+	}
+	return []string{"NAME", "CONNECTION", "USERNAME"}
+}
+
+func (obj *SshKey) Rows(wide bool) [][]string {
+	row := []string{obj.Id, obj.ConnectionName, app.None(obj.Username)}
+	if wide {
+		row = append(row, app.None(obj.CspSshKeyName), app.None(obj.Fingerprint))
+	}
+	return [][]string{row}
+}
+
+func (obj *SshKeyList) Columns(wide bool) []string {
+	return (&SshKey{}).Columns(wide)
+}
+
+func (obj *SshKeyList) Rows(wide bool) [][]string {
+	rows := [][]string{}
+	for i := range obj.Items {
+		rows = append(rows, obj.Items[i].Rows(wide)...)
+	}
+	return rows
+}
+
+// image
+func (obj *Image) Columns(wide bool) []string {
+	if wide {
+		return []string{"NAME", "CONNECTION", "CSP-IMAGE", "GUEST-OS", "STATUS", "CREATED"}
+	}
+	return []string{"NAME", "CONNECTION", "CSP-IMAGE", "GUEST-OS"}
+}
+
+func (obj *Image) Rows(wide bool) [][]string {
+	row := []string{obj.Id, obj.ConnectionName, app.None(obj.CspImageId), app.None(obj.GuestOS)}
+	if wide {
+		row = append(row, app.None(obj.Status), app.None(obj.CreationDate))
+	}
+	return [][]string{row}
+}
+
+func (obj *ImageList) Columns(wide bool) []string {
+	return (&Image{}).Columns(wide)
+}
+
+func (obj *ImageList) Rows(wide bool) [][]string {
+	rows := [][]string{}
+	for i := range obj.Items {
+		rows = append(rows, obj.Items[i].Rows(wide)...)
+	}
+	return rows
+}
+
+// spec
+func (obj *Spec) Columns(wide bool) []string {
+	if wide {
+		return []string{"NAME", "CONNECTION", "CSP-SPEC", "OS-TYPE"}
+	}
+	return []string{"NAME", "CONNECTION", "CSP-SPEC"}
+}
+
+func (obj *Spec) Rows(wide bool) [][]string {
+	row := []string{obj.Id, obj.ConnectionName, app.None(obj.CspSpecName)}
+	if wide {
+		row = append(row, app.None(obj.OsType))
+	}
+	return [][]string{row}
+}
+
+func (obj *SpecList) Columns(wide bool) []string {
+	return (&Spec{}).Columns(wide)
+}
+
+func (obj *SpecList) Rows(wide bool) [][]string {
+	rows := [][]string{}
+	for i := range obj.Items {
+		rows = append(rows, obj.Items[i].Rows(wide)...)
+	}
+	return rows
+}
+
+// mcis
+func (obj *MCIS) Columns(wide bool) []string {
+	if wide {
+		return []string{"NAME", "STATUS", "VMS", "TARGET-ACTION", "LABEL", "DESCRIPTION"}
+	}
+	return []string{"NAME", "STATUS", "VMS"}
+}
+
+func (obj *MCIS) Rows(wide bool) [][]string {
+	row := []string{obj.Id, app.None(obj.Status), fmt.Sprint(len(obj.VM))}
+	if wide {
+		row = append(row, app.None(obj.TargetAction), app.None(obj.Label), app.None(obj.Description))
+	}
+	return [][]string{row}
+}
+
+func (obj *MCISList) Columns(wide bool) []string {
+	return (&MCIS{}).Columns(wide)
+}
+
+func (obj *MCISList) Rows(wide bool) [][]string {
+	rows := [][]string{}
+	for i := range obj.Items {
+		rows = append(rows, obj.Items[i].Rows(wide)...)
+	}
+	return rows
+}
+
+// verification
+func (obj *VerificationList) Columns(wide bool) []string {
+	if wide {
+		return []string{"CONNECTION", "CHECK", "RESULT", "ELAPSED", "MESSAGE"}
+	}
+	return []string{"CONNECTION", "CHECK", "RESULT", "MESSAGE"}
+}
+
+func (obj *VerificationList) Rows(wide bool) [][]string {
+	rows := [][]string{}
+	for _, v := range obj.Items {
+		if wide {
+			rows = append(rows, []string{v.Connection, v.Check, v.Result, v.Elapsed, app.None(v.Message)})
+		} else {
+			rows = append(rows, []string{v.Connection, v.Check, v.Result, app.None(v.Message)})
+		}
+	}
+	return rows
+}
@@ -0,0 +1,44 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/itnpeople/cbctl/app"
+)
+
+// applies TLS settings of the context (a CA bundle, a client certificate and insecure-skip-verify)
+func (c *Client) setTLS() error {
+
+	conf := c.Context.GetTLS(c.Service)
+	if conf == nil {
+		return nil
+	}
+	if err := conf.Validate(); err != nil {
+		return err
+	}
+
+	cfg := &tls.Config{InsecureSkipVerify: conf.InsecureSkipVerify}
+	if conf.CA != "" {
+		pem, err := ioutil.ReadFile(app.ExpandPath(conf.CA))
+		if err != nil {
+			return err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("No certificates found in a CA bundle (path=%s)", conf.CA)
+		}
+		cfg.RootCAs = pool
+	}
+	if conf.Cert != "" {
+		cert, err := tls.LoadX509KeyPair(app.ExpandPath(conf.Cert), app.ExpandPath(conf.Key))
+		if err != nil {
+			return err
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	c.http.SetTLSClientConfig(cfg)
+	return nil
+}
@@ -0,0 +1,288 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/itnpeople/cbctl/app"
+)
+
+const (
+	RESOURCE_VNET           = "vNet"
+	RESOURCE_SECURITY_GROUP = "securityGroup"
+	RESOURCE_SSHKEY         = "sshKey"
+	RESOURCE_IMAGE          = "image"
+	RESOURCE_SPEC           = "spec"
+)
+
+// a CB-Tumblebug client
+type TumblebugClient struct {
+	*Client
+}
+
+type SimpleMsg struct {
+	Message string `json:"message"`
+}
+
+type Namespace struct {
+	Raw
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type NamespaceList struct {
+	Raw
+	Items []Namespace `json:"ns"`
+}
+
+type Subnet struct {
+	Id        string `json:"Id,omitempty"`
+	Name      string `json:"Name"`
+	IPv4_CIDR string `json:"IPv4_CIDR"`
+}
+
+type VNet struct {
+	Raw
+	Id             string   `json:"id"`
+	Name           string   `json:"name"`
+	ConnectionName string   `json:"connectionName"`
+	CidrBlock      string   `json:"cidrBlock"`
+	SubnetInfoList []Subnet `json:"subnetInfoList"`
+	Description    string   `json:"description"`
+	CspVNetId      string   `json:"cspVNetId"`
+	CspVNetName    string   `json:"cspVNetName"`
+	Status         string   `json:"status"`
+}
+
+type VNetList struct {
+	Raw
+	Items []VNet `json:"vNet"`
+}
+
+type FirewallRule struct {
+	FromPort   string `json:"fromPort"`
+	ToPort     string `json:"toPort"`
+	IPProtocol string `json:"ipProtocol"`
+	Direction  string `json:"direction"`
+	CIDR       string `json:"cidr,omitempty"`
+}
+
+type SecurityGroup struct {
+	Raw
+	Id                   string         `json:"id"`
+	Name                 string         `json:"name"`
+	ConnectionName       string         `json:"connectionName"`
+	VNetId               string         `json:"vNetId"`
+	Description          string         `json:"description"`
+	FirewallRules        []FirewallRule `json:"firewallRules"`
+	CspSecurityGroupId   string         `json:"cspSecurityGroupId"`
+	CspSecurityGroupName string         `json:"cspSecurityGroupName"`
+}
+
+type SecurityGroupList struct {
+	Raw
+	Items []SecurityGroup `json:"securityGroup"`
+}
+
+type SshKey struct {
+	Raw
+	Id               string `json:"id"`
+	Name             string `json:"name"`
+	ConnectionName   string `json:"connectionName"`
+	Description      string `json:"description"`
+	CspSshKeyName    string `json:"cspSshKeyName"`
+	Fingerprint      string `json:"fingerprint"`
+	Username         string `json:"username"`
+	VerifiedUsername string `json:"verifiedUsername"`
+	PublicKey        string `json:"publicKey"`
+	PrivateKey       string `json:"privateKey"`
+}
+
+type SshKeyList struct {
+	Raw
+	Items []SshKey `json:"sshKey"`
+}
+
+type Image struct {
+	Raw
+	Id             string `json:"id"`
+	Name           string `json:"name"`
+	ConnectionName string `json:"connectionName"`
+	CspImageId     string `json:"cspImageId"`
+	CspImageName   string `json:"cspImageName"`
+	Description    string `json:"description"`
+	CreationDate   string `json:"creationDate"`
+	GuestOS        string `json:"guestOS"`
+	Status         string `json:"status"`
+}
+
+type ImageList struct {
+	Raw
+	Items []Image `json:"image"`
+}
+
+type Spec struct {
+	Raw
+	Id             string `json:"id"`
+	Name           string `json:"name"`
+	ConnectionName string `json:"connectionName"`
+	CspSpecName    string `json:"cspSpecName"`
+	OsType         string `json:"os_type"`
+	Description    string `json:"description"`
+}
+
+type SpecList struct {
+	Raw
+	Items []Spec `json:"spec"`
+}
+
+type VM struct {
+	Id             string `json:"id"`
+	Name           string `json:"name"`
+	ConnectionName string `json:"connectionName"`
+	SpecId         string `json:"specId"`
+	ImageId        string `json:"imageId"`
+	VNetId         string `json:"vNetId"`
+	SubnetId       string `json:"subnetId"`
+	SshKeyId       string `json:"sshKeyId"`
+	PublicIP       string `json:"publicIP"`
+	PrivateIP      string `json:"privateIP"`
+	Status         string `json:"status"`
+	TargetStatus   string `json:"targetStatus"`
+	TargetAction   string `json:"targetAction"`
+	Description    string `json:"description"`
+}
+
+type MCIS struct {
+	Raw
+	Id              string `json:"id"`
+	Name            string `json:"name"`
+	Status          string `json:"status"`
+	TargetStatus    string `json:"targetStatus"`
+	TargetAction    string `json:"targetAction"`
+	InstallMonAgent string `json:"installMonAgent"`
+	Label           string `json:"label"`
+	Description     string `json:"description"`
+	VM              []VM   `json:"vm"`
+}
+
+type MCISList struct {
+	Raw
+	Items []MCIS `json:"mcis"`
+}
+
+// returns a CB-Tumblebug client of the context
+func NewTumblebug(ctx *app.ConfigContext) *TumblebugClient {
+	return &TumblebugClient{Client: newClient(ctx, SERVICE_TUMBLEBUG, ctx.Urls.Tumblebug)}
+}
+
+func (c *TumblebugClient) ListNamespaces() (*NamespaceList, error) {
+	res := &NamespaceList{}
+	return res, c.get("/ns", res)
+}
+
+func (c *TumblebugClient) GetNamespace(name string) (*Namespace, error) {
+	res := &Namespace{}
+	return res, c.get("/ns/"+name, res)
+}
+
+func (c *TumblebugClient) CreateNamespace(body interface{}) (*Namespace, error) {
+	res := &Namespace{}
+	return res, c.post("/ns", body, res)
+}
+
+func (c *TumblebugClient) DeleteNamespace(name string) (*SimpleMsg, error) {
+	res := &SimpleMsg{}
+	return res, c.delete("/ns/"+name, res)
+}
+
+// path of MCIRs (name is optional)
+func resourcePath(namespace string, kind string, name string) string {
+	path := fmt.Sprintf("/ns/%s/resources/%s", namespace, kind)
+	if name != "" {
+		path += "/" + name
+	}
+	return path
+}
+
+// deletes a MCIR (if name is empty, deletes all MCIRs of the kind)
+func (c *TumblebugClient) DeleteResource(namespace string, kind string, name string) (*SimpleMsg, error) {
+	res := &SimpleMsg{}
+	return res, c.delete(resourcePath(namespace, kind, name), res)
+}
+
+func (c *TumblebugClient) ListVNets(namespace string) (*VNetList, error) {
+	res := &VNetList{}
+	return res, c.get(resourcePath(namespace, RESOURCE_VNET, ""), res)
+}
+
+func (c *TumblebugClient) GetVNet(namespace string, name string) (*VNet, error) {
+	res := &VNet{}
+	return res, c.get(resourcePath(namespace, RESOURCE_VNET, name), res)
+}
+
+func (c *TumblebugClient) ListSecurityGroups(namespace string) (*SecurityGroupList, error) {
+	res := &SecurityGroupList{}
+	return res, c.get(resourcePath(namespace, RESOURCE_SECURITY_GROUP, ""), res)
+}
+
+func (c *TumblebugClient) GetSecurityGroup(namespace string, name string) (*SecurityGroup, error) {
+	res := &SecurityGroup{}
+	return res, c.get(resourcePath(namespace, RESOURCE_SECURITY_GROUP, name), res)
+}
+
+func (c *TumblebugClient) ListSshKeys(namespace string) (*SshKeyList, error) {
+	res := &SshKeyList{}
+	return res, c.get(resourcePath(namespace, RESOURCE_SSHKEY, ""), res)
+}
+
+func (c *TumblebugClient) GetSshKey(namespace string, name string) (*SshKey, error) {
+	res := &SshKey{}
+	return res, c.get(resourcePath(namespace, RESOURCE_SSHKEY, name), res)
+}
+
+func (c *TumblebugClient) ListImages(namespace string) (*ImageList, error) {
+	res := &ImageList{}
+	return res, c.get(resourcePath(namespace, RESOURCE_IMAGE, ""), res)
+}
+
+func (c *TumblebugClient) GetImage(namespace string, name string) (*Image, error) {
+	res := &Image{}
+	return res, c.get(resourcePath(namespace, RESOURCE_IMAGE, name), res)
+}
+
+func (c *TumblebugClient) ListSpecs(namespace string) (*SpecList, error) {
+	res := &SpecList{}
+	return res, c.get(resourcePath(namespace, RESOURCE_SPEC, ""), res)
+}
+
+func (c *TumblebugClient) GetSpec(namespace string, name string) (*Spec, error) {
+	res := &Spec{}
+	return res, c.get(resourcePath(namespace, RESOURCE_SPEC, name), res)
+}
+
+func (c *TumblebugClient) ListMCIS(namespace string) (*MCISList, error) {
+	res := &MCISList{}
+	return res, c.get(fmt.Sprintf("/ns/%s/mcis", namespace), res)
+}
+
+func (c *TumblebugClient) GetMCIS(namespace string, name string) (*MCIS, error) {
+	res := &MCIS{}
+	return res, c.get(fmt.Sprintf("/ns/%s/mcis/%s", namespace, name), res)
+}
+
+// deletes a MCIS (action : terminate, refine or empty)
+func (c *TumblebugClient) DeleteMCIS(namespace string, name string, action string) (*SimpleMsg, error) {
+	path := fmt.Sprintf("/ns/%s/mcis/%s", namespace, name)
+	if action != "" {
+		path += "?action=" + action
+	}
+	res := &SimpleMsg{}
+	return res, c.delete(path, res)
+}
+
+// deletes all MCISs of the namespace
+func (c *TumblebugClient) DeleteAllMCIS(namespace string) (*SimpleMsg, error) {
+	res := &SimpleMsg{}
+	return res, c.delete(fmt.Sprintf("/ns/%s/mcis", namespace), res)
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const (
+	VERIFY_PASS = "Pass"
+	VERIFY_FAIL = "Fail"
+)
+
+// read-only checks of a connection (requests to the CSP through the connection)
+//   - vmspec : lists VM specs of the region (credential, region)
+//   - vpc    : lists VPCs of the region (credential, network permissions)
+var VERIFY_CHECKS = []struct {
+	Name string
+	Path string
+}{
+	{"vmspec", "/vmspec"},
+	{"vpc", "/vpc"},
+}
+
+// a result of a check
+type Verification struct {
+	Connection string `json:"connection"`
+	Check      string `json:"check"`
+	Result     string `json:"result"`
+	Elapsed    string `json:"elapsed"`
+	Message    string `json:"message,omitempty"`
+}
+
+type VerificationList struct {
+	Items []Verification `json:"items"`
+}
+
+// returns whether any check failed
+func (obj *VerificationList) Failed() bool {
+	for _, v := range obj.Items {
+		if v.Result != VERIFY_PASS {
+			return true
+		}
+	}
+	return false
+}
+
+// verifies a connection works with read-only calls (errors of checks are results, not errors)
+func (c *SpiderClient) VerifyConnection(name string) (*VerificationList, error) {
+
+	if _, err := c.GetConnection(name); err != nil {
+		return nil, err
+	}
+	res := &VerificationList{Items: []Verification{}}
+	for _, check := range VERIFY_CHECKS {
+		v := Verification{Connection: name, Check: check.Name, Result: VERIFY_PASS}
+		start := time.Now()
+		err := c.get(fmt.Sprintf("%s?ConnectionName=%s", check.Path, url.QueryEscape(name)), nil)
+		v.Elapsed = time.Since(start).Round(time.Millisecond).String()
+		if err != nil {
+			v.Result, v.Message = VERIFY_FAIL, err.Error()
+			var e *APIError
+			if errors.As(err, &e) {
+				v.Message = e.Message // an error message of the CSP
+			}
+		}
+		res.Items = append(res.Items, v)
+	}
+	return res, nil
+}
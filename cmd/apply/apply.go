@@ -0,0 +1,263 @@
+package apply
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/itnpeople/cbctl/app"
+	"github.com/itnpeople/cbctl/client"
+	"github.com/itnpeople/cbctl/schema"
+	"github.com/itnpeople/cbctl/utils"
+)
+
+// kinds of a manifest (in order of dependencies)
+var KINDS = []string{"Namespace", "Driver", "Credential", "Region", "Connection", "Cluster", "NodePool"}
+
+// name fields of kinds
+var nameFields = map[string]string{
+	"Namespace":  "name",
+	"Driver":     "DriverName",
+	"Credential": "CredentialName",
+	"Region":     "RegionName",
+	"Connection": "ConfigName",
+	"Cluster":    "name",
+	"NodePool":   "cluster",
+}
+
+// a object of a manifest
+type object struct {
+	Kind      string
+	Name      string
+	Namespace string
+	Body      map[string]interface{}
+	order     int
+}
+
+// a struct to support command
+type ApplyOptions struct {
+	*app.Options
+	objects []object
+}
+
+// validates and parses documents
+func (o *ApplyOptions) Validate() error {
+
+	if o.Filename == "" {
+		return fmt.Errorf("Filename is required.")
+	}
+	buf, err := app.ReadFile(o.Filename)
+	if err != nil {
+		return err
+	}
+	docs, err := app.SplitDocuments(buf)
+	if err != nil {
+		return err
+	}
+
+	o.objects = []object{}
+	for i, doc := range docs {
+		obj := object{Body: map[string]interface{}{}}
+		if err := json.Unmarshal(doc, &obj.Body); err != nil {
+			return fmt.Errorf("Invalid document (document=%d, cause=%v)", i+1, err)
+		}
+		kind := toString(pop(obj.Body, "kind"))
+		for idx, k := range KINDS {
+			if strings.EqualFold(k, kind) {
+				obj.Kind, obj.order = k, idx
+			}
+		}
+		if obj.Kind == "" {
+			return fmt.Errorf("Not supported kind (document=%d, kind=%s, allowed=%s)", i+1, kind, strings.Join(KINDS, ","))
+		}
+
+		switch obj.Kind {
+		case "Cluster", "NodePool":
+			obj.Namespace = utils.NVL(toString(pop(obj.Body, "namespace")), utils.NVL(o.Namespace, app.Config.GetCurrentContext().Namespace))
+			if obj.Namespace == "" {
+				return fmt.Errorf("Namespace is required (document=%d, kind=%s)", i+1, obj.Kind)
+			}
+		}
+		if obj.Kind == "NodePool" {
+			obj.Name = toString(pop(obj.Body, "cluster"))
+		} else {
+			obj.Name = toString(valueOf(obj.Body, nameFields[obj.Kind]))
+		}
+		if obj.Name == "" {
+			return fmt.Errorf("Name is required (document=%d, kind=%s, field=%s)", i+1, obj.Kind, nameFields[obj.Kind])
+		}
+		if b, err := json.Marshal(obj.Body); err != nil {
+			return err
+		} else if warnings, err := schema.Validate(schema.KindOf(obj.Kind), b); err != nil {
+			return fmt.Errorf("document %d (%s/%s) : %w", i+1, strings.ToLower(obj.Kind), obj.Name, err)
+		} else {
+			schema.PrintWarnings(fmt.Sprintf("document %d (%s/%s)", i+1, strings.ToLower(obj.Kind), obj.Name), warnings)
+		}
+		o.objects = append(o.objects, obj)
+	}
+	if len(o.objects) == 0 {
+		return fmt.Errorf("No objects found (filename=%s)", o.Filename)
+	}
+
+	// order by dependencies (keeps an order of the file in same kinds)
+	sort.SliceStable(o.objects, func(i, j int) bool {
+		return o.objects[i].order < o.objects[j].order
+	})
+	return nil
+}
+
+// creates objects that don't exist
+func (o *ApplyOptions) Run() error {
+
+	ctx := app.Config.GetCurrentContext()
+	mcks, spider, tumblebug := client.NewMCKS(ctx), client.NewSpider(ctx), client.NewTumblebug(ctx)
+
+	for _, obj := range o.objects {
+		kind := strings.ToLower(obj.Kind)
+
+		// node-pool : adds insufficient worker nodes
+		if obj.Kind == "NodePool" {
+			added, err := o.applyNodePool(mcks, spider, obj)
+			if err != nil {
+				return fmt.Errorf("%s/%s : %w", kind, obj.Name, err)
+			}
+			if added > 0 {
+				o.Println("%s/%s scaled (added=%d)", kind, obj.Name, added)
+			} else {
+				o.Println("%s/%s unchanged", kind, obj.Name)
+			}
+			continue
+		}
+
+		// exists
+		var err error
+		switch obj.Kind {
+		case "Namespace":
+			_, err = tumblebug.GetNamespace(obj.Name)
+		case "Driver":
+			_, err = spider.GetDriver(obj.Name)
+		case "Credential":
+			_, err = spider.GetCredential(obj.Name)
+		case "Region":
+			_, err = spider.GetRegion(obj.Name)
+		case "Connection":
+			_, err = spider.GetConnection(obj.Name)
+		case "Cluster":
+			_, err = mcks.GetCluster(obj.Namespace, obj.Name)
+		}
+		if err == nil {
+			o.Println("%s/%s unchanged", kind, obj.Name)
+			continue
+		} else if !client.IsNotFound(err) {
+			return fmt.Errorf("%s/%s : %w", kind, obj.Name, err)
+		}
+
+		// create
+		switch obj.Kind {
+		case "Namespace":
+			_, err = tumblebug.CreateNamespace(obj.Body)
+		case "Driver":
+			_, err = spider.CreateDriver(obj.Body)
+		case "Credential":
+			_, err = spider.CreateCredential(obj.Body)
+		case "Region":
+			_, err = spider.CreateRegion(obj.Body)
+		case "Connection":
+			_, err = spider.CreateConnection(obj.Body)
+		case "Cluster":
+			_, err = mcks.CreateCluster(obj.Namespace, obj.Body)
+		}
+		if err != nil {
+			return fmt.Errorf("%s/%s : %w", kind, obj.Name, err)
+		}
+		o.Println("%s/%s created", kind, obj.Name)
+	}
+	return nil
+}
+
+// adds worker nodes as many as insufficient (matched by a location of the connection and a spec, fails if a node is not identified)
+func (o *ApplyOptions) applyNodePool(mcks *client.MCKSClient, spider *client.SpiderClient, obj object) (int, error) {
+
+	nodes, err := mcks.ListNodes(obj.Namespace, obj.Name)
+	if err != nil {
+		return 0, err
+	}
+	locations, err := spider.ConnectionLocations()
+	if err != nil {
+		return 0, err
+	}
+
+	pools := []interface{}{}
+	added := 0
+	workers, _ := obj.Body["worker"].([]interface{})
+	for _, w := range workers {
+		pool, _ := w.(map[string]interface{})
+		connection, spec := toString(pool["connection"]), toString(pool["spec"])
+		count := 0
+		if c, ok := pool["count"].(float64); ok {
+			count = int(c)
+		}
+		current, err := client.PoolNodes(nodes.Items, client.ROLE_WORKER, connection, spec, locations)
+		if err != nil {
+			return 0, &app.ExitError{Code: app.EXIT_ERROR, Message: err.Error()}
+		}
+		count -= len(current)
+		if count > 0 {
+			pools = append(pools, map[string]interface{}{"connection": connection, "count": count, "spec": spec})
+			added += count
+		}
+	}
+	if added == 0 {
+		return 0, nil
+	}
+	if _, err := mcks.CreateNode(obj.Namespace, obj.Name, map[string]interface{}{"worker": pools}); err != nil {
+		return 0, err
+	}
+	return added, nil
+}
+
+// removes and returns a value of the key
+func pop(body map[string]interface{}, key string) interface{} {
+	v := body[key]
+	delete(body, key)
+	return v
+}
+
+// returns a value of the key (case-insensitive)
+func valueOf(body map[string]interface{}, key string) interface{} {
+	for k, v := range body {
+		if strings.EqualFold(k, key) {
+			return v
+		}
+	}
+	return nil
+}
+
+// returns a string value ("" if nil)
+func toString(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+// returns a cobra command
+func NewCommandApply(options *app.Options) *cobra.Command {
+
+	o := &ApplyOptions{
+		Options: options,
+	}
+
+	return &cobra.Command{
+		Use:                   "apply -f FILENAME [options]",
+		Short:                 "Create objects of a multi-document yaml file if they don't exist",
+		DisableFlagsInUseLine: true,
+		Run: func(c *cobra.Command, args []string) {
+			app.ValidateError(c, o.Validate())
+			app.ValidateError(c, o.Run())
+		},
+	}
+}
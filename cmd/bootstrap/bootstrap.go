@@ -0,0 +1,314 @@
+package bootstrap
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/itnpeople/cbctl/app"
+	"github.com/itnpeople/cbctl/client"
+	"github.com/itnpeople/cbctl/cmd/create"
+)
+
+const (
+	STATUS_CREATED     = "created"
+	STATUS_REUSED      = "reused"
+	STATUS_ROLLED_BACK = "rolled-back"
+	STATUS_FAILED      = "failed"
+)
+
+// a struct to support command
+type BootstrapOptions struct {
+	*app.Options
+	CSP            string
+	Region         string
+	Zone           string
+	Location       string
+	ResourceGroup  string
+	CredentialFile string
+	Profile        string
+	steps          []*step
+}
+
+// a step of bootstrap (a spider object)
+type step struct {
+	Kind     string
+	Name     string
+	Status   string
+	rollback func() error
+}
+
+// returns a region (or location) and a zone suffix of names (eg. "asia-northeast1-a")
+func (o *BootstrapOptions) location() string {
+	if o.Zone != "" {
+		return o.Zone
+	}
+	if o.CSP == "azure" {
+		return o.Location
+	}
+	return o.Region
+}
+
+// names of objects
+//   - driver     : <csp>-driver-v1.0
+//   - credential : <csp>-credential
+//   - region     : <csp>-<zone|region>-region
+//   - connection : <csp>-<zone|region>
+func (o *BootstrapOptions) CredentialName() string {
+	return fmt.Sprintf("%s-credential", o.CSP)
+}
+
+func (o *BootstrapOptions) RegionName() string {
+	return fmt.Sprintf("%s-%s-region", o.CSP, o.location())
+}
+
+func (o *BootstrapOptions) ConnectionName() string {
+	return fmt.Sprintf("%s-%s", o.CSP, o.location())
+}
+
+// returns keys of a region of the CSP with values (azure : location, ResourceGroup and Zone, others : Region and Zone)
+// a request body has keys of non-empty values only
+func (o *BootstrapOptions) regionKeys() []client.KeyValue {
+	if o.CSP == "azure" {
+		return []client.KeyValue{{Key: "location", Value: o.Location}, {Key: "ResourceGroup", Value: o.ResourceGroup}, {Key: "Zone", Value: o.Zone}}
+	}
+	return []client.KeyValue{{Key: "Region", Value: o.Region}, {Key: "Zone", Value: o.Zone}}
+}
+
+// validates
+func (o *BootstrapOptions) Validate() error {
+	o.CSP = strings.ToLower(o.CSP)
+	if o.CSP == "" {
+		return fmt.Errorf("CSP is required.")
+	}
+	if o.Filename != "" {
+		return fmt.Errorf("A file (-f) is not supported, use 'cbctl apply' instead.")
+	}
+	if o.CSP == "azure" {
+		if o.Location == "" || o.ResourceGroup == "" {
+			return fmt.Errorf("Invalid location/resource-group flag (csp=%s, location=%s, resource-group=%s)", o.CSP, o.Location, o.ResourceGroup)
+		}
+	} else if o.Region == "" {
+		return fmt.Errorf("Region is required.")
+	}
+	return nil
+}
+
+// creates or reuses a driver, a credential, a region and a connection (rolls back created objects if a step fails)
+func (o *BootstrapOptions) Run() error {
+
+	spider := client.NewSpider(app.Config.GetCurrentContext())
+	o.steps = []*step{}
+
+	err := func() error {
+		driver := client.DriverName(o.CSP)
+
+		// driver
+		if err := o.apply("driver", driver,
+			func() error {
+				_, err := spider.GetDriver(driver)
+				return err
+			},
+			func() error {
+				_, err := spider.CreateDriver(&client.Driver{
+					DriverName:        driver,
+					ProviderName:      strings.ToUpper(o.CSP),
+					DriverLibFileName: driver + ".so",
+				})
+				return err
+			},
+			func() error {
+				_, err := spider.DeleteDriver(driver)
+				return err
+			}); err != nil {
+			return err
+		}
+
+		// credential
+		if err := o.apply("credential", o.CredentialName(),
+			func() error {
+				if obj, err := spider.GetCredential(o.CredentialName()); err != nil {
+					return err
+				} else if !strings.EqualFold(obj.ProviderName, o.CSP) {
+					return fmt.Errorf("A credential exists with a different provider (provider=%s)", obj.ProviderName)
+				}
+				return nil
+			},
+			func() error {
+				body, err := o.credential()
+				if err != nil {
+					return err
+				}
+				_, err = spider.CreateCredential(body)
+				return err
+			},
+			func() error {
+				_, err := spider.DeleteCredential(o.CredentialName())
+				return err
+			}); err != nil {
+			return err
+		}
+
+		// region
+		region := &client.Region{
+			RegionName:       o.RegionName(),
+			ProviderName:     strings.ToUpper(o.CSP),
+			KeyValueInfoList: []client.KeyValue{},
+		}
+		for _, kv := range o.regionKeys() {
+			if kv.Value != "" {
+				region.KeyValueInfoList = append(region.KeyValueInfoList, kv)
+			}
+		}
+		if err := o.apply("region", region.RegionName,
+			func() error {
+				obj, err := spider.GetRegion(region.RegionName)
+				if err != nil {
+					return err
+				}
+				for _, kv := range o.regionKeys() {
+					value := ""
+					for _, e := range obj.KeyValueInfoList {
+						if strings.EqualFold(e.Key, kv.Key) {
+							value = e.Value
+						}
+					}
+					if value != kv.Value {
+						return fmt.Errorf("A region exists with a different value (key=%s, value=%s, expected=%s)", kv.Key, value, kv.Value)
+					}
+				}
+				return nil
+			},
+			func() error {
+				_, err := spider.CreateRegion(region)
+				return err
+			},
+			func() error {
+				_, err := spider.DeleteRegion(region.RegionName)
+				return err
+			}); err != nil {
+			return err
+		}
+
+		// connection
+		connection := &client.Connection{
+			ConfigName:     o.ConnectionName(),
+			ProviderName:   strings.ToUpper(o.CSP),
+			DriverName:     driver,
+			CredentialName: o.CredentialName(),
+			RegionName:     region.RegionName,
+		}
+		return o.apply("connection", connection.ConfigName,
+			func() error {
+				obj, err := spider.GetConnection(connection.ConfigName)
+				if err != nil {
+					return err
+				}
+				if obj.DriverName != connection.DriverName || obj.CredentialName != connection.CredentialName || obj.RegionName != connection.RegionName {
+					return fmt.Errorf("A connection exists with different objects (driver=%s, credential=%s, region=%s)", obj.DriverName, obj.CredentialName, obj.RegionName)
+				}
+				return nil
+			},
+			func() error {
+				_, err := spider.CreateConnection(connection)
+				return err
+			},
+			func() error {
+				_, err := spider.DeleteConnection(connection.ConfigName)
+				return err
+			})
+	}()
+
+	// rollback (in reverse order)
+	if err != nil {
+		for i := len(o.steps) - 1; i >= 0; i-- {
+			s := o.steps[i]
+			if s.Status != STATUS_CREATED {
+				continue
+			}
+			if e := s.rollback(); e != nil && !client.IsNotFound(e) {
+				o.PrintlnError(fmt.Errorf("Failed to roll back %s/%s (cause=%v)", s.Kind, s.Name, e))
+			} else {
+				s.Status = STATUS_ROLLED_BACK
+			}
+		}
+	}
+	o.printSummary()
+	return err
+}
+
+// reuses a object if it exists, otherwise creates it
+func (o *BootstrapOptions) apply(kind string, name string, get func() error, create func() error, rollback func() error) error {
+
+	s := &step{Kind: kind, Name: name, rollback: rollback}
+	o.steps = append(o.steps, s)
+
+	err := get()
+	if err == nil {
+		s.Status = STATUS_REUSED
+		return nil
+	} else if !client.IsNotFound(err) {
+		s.Status = STATUS_FAILED
+		return fmt.Errorf("%s/%s : %w", kind, name, err)
+	}
+	if err := create(); err != nil {
+		s.Status = STATUS_FAILED
+		return fmt.Errorf("%s/%s : %w", kind, name, err)
+	}
+	s.Status = STATUS_CREATED
+	return nil
+}
+
+// returns a credential request body (from a credential file or prompts)
+func (o *BootstrapOptions) credential() ([]byte, error) {
+
+	options := *o.Options
+	options.Name = o.CredentialName()
+	cred := &create.CredentialOptions{
+		Options:  &options,
+		CSP:      o.CSP,
+		FromFile: o.CredentialFile,
+		Profile:  o.Profile,
+	}
+	if err := cred.Validate(); err != nil {
+		return nil, err
+	}
+	return cred.Body()
+}
+
+// prints a summary of steps
+func (o *BootstrapOptions) printSummary() {
+	rows := [][]string{}
+	for _, s := range o.steps {
+		rows = append(rows, []string{s.Kind, s.Name, s.Status})
+	}
+	app.PrintTable(o.OutStream, []string{"KIND", "NAME", "STATUS"}, rows)
+}
+
+// returns a cobra command
+func NewCommandBootstrap(options *app.Options) *cobra.Command {
+
+	o := &BootstrapOptions{
+		Options: options,
+	}
+
+	cmd := &cobra.Command{
+		Use:                   "bootstrap --csp CSP (--region REGION [--zone ZONE] | --location LOCATION --resource-group GROUP) [options]",
+		Short:                 "Create (or reuse) a driver, a credential, a region and a connection of a CSP",
+		DisableFlagsInUseLine: true,
+		Run: func(c *cobra.Command, args []string) {
+			app.ValidateError(c, o.Validate())
+			app.ValidateError(c, o.Run())
+		},
+	}
+	cmd.Flags().StringVar(&o.CSP, "csp", "", "Cloud service provider (aws, gcp, azure, alibaba, tencent, ibm, openstack, cloudit)")
+	cmd.Flags().StringVar(&o.Region, "region", "", "Region")
+	cmd.Flags().StringVar(&o.Zone, "zone", "", "Zone")
+	cmd.Flags().StringVar(&o.Location, "location", "", "Location (azure)")
+	cmd.Flags().StringVar(&o.ResourceGroup, "resource-group", "", "Resource group (azure)")
+	cmd.Flags().StringVar(&o.CredentialFile, "credential-file", "", "Native credential file of the CSP (eg. ~/.aws/credentials, a GCP service-account key)")
+	cmd.Flags().StringVar(&o.Profile, "profile", "", "Profile of a credential file (aws, default: default)")
+
+	return cmd
+}
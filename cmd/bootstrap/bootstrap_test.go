@@ -0,0 +1,24 @@
+package bootstrap
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/itnpeople/cbctl/client"
+)
+
+func TestRegionKeys(t *testing.T) {
+	tests := []struct {
+		o    BootstrapOptions
+		want []client.KeyValue
+	}{
+		{BootstrapOptions{CSP: "aws", Region: "ap-northeast-1", Zone: "ap-northeast-1a"}, []client.KeyValue{{Key: "Region", Value: "ap-northeast-1"}, {Key: "Zone", Value: "ap-northeast-1a"}}},
+		{BootstrapOptions{CSP: "gcp", Region: "asia-northeast1"}, []client.KeyValue{{Key: "Region", Value: "asia-northeast1"}, {Key: "Zone", Value: ""}}},
+		{BootstrapOptions{CSP: "azure", Location: "japaneast", ResourceGroup: "rg"}, []client.KeyValue{{Key: "location", Value: "japaneast"}, {Key: "ResourceGroup", Value: "rg"}, {Key: "Zone", Value: ""}}},
+	}
+	for _, tt := range tests {
+		if got := tt.o.regionKeys(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("regionKeys(%s) = %v, want %v", tt.o.CSP, got, tt.want)
+		}
+	}
+}
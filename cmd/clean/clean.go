@@ -2,11 +2,12 @@ package clean
 
 import (
 	"fmt"
+	"os"
 
-	"github.com/go-resty/resty/v2"
 	"github.com/spf13/cobra"
 
 	"github.com/itnpeople/cbctl/app"
+	"github.com/itnpeople/cbctl/client"
 	"github.com/itnpeople/cbctl/utils"
 )
 
@@ -30,48 +31,65 @@ func (o *CleanOptions) RunCleanupMCIS() error {
 		return fmt.Errorf("Namespace is required.")
 	}
 
-	url := fmt.Sprintf("%s/ns/%s", app.Config.GetCurrentContext().Urls.Tumblebug, o.Namespace)
-	http := resty.New().SetDisableWarn(true).R().SetBasicAuth("default", "default")
+	tumblebug := client.NewTumblebug(app.Config.GetCurrentContext())
 
-	// mcis
-	if resp, err := http.Delete(url + "/mcis"); err != nil {
-		return err
-	} else {
-		o.WriteBody(resp.Body())
-	}
-	// vpc
-	if resp, err := http.Delete(url + "/resources/vNet"); err != nil {
-		return err
-	} else {
-		o.WriteBody(resp.Body())
+	// confirm (objects are listed only to be prompted)
+	objects := []string{}
+	if o.Prompts() && app.IsTerminal() {
+		objects = o.listMCIR(tumblebug)
 	}
-	// securityGroup
-	if resp, err := http.Delete(url + "/resources/securityGroup"); err != nil {
+	if ok, err := o.Confirm(app.Confirmation{Action: "deleted", Namespace: o.Namespace, Objects: objects}); err != nil {
 		return err
-	} else {
-		o.WriteBody(resp.Body())
+	} else if !ok {
+		fmt.Fprintln(os.Stderr, "Cancelled")
+		return nil
 	}
-	// sshKey
-	if resp, err := http.Delete(url + "/resources/sshKey"); err != nil {
+
+	// mcis
+	if res, err := tumblebug.DeleteAllMCIS(o.Namespace); err != nil {
 		return err
-	} else {
-		o.WriteBody(resp.Body())
-	}
-	// image
-	if resp, err := http.Delete(url + "/resources/image"); err != nil {
+	} else if err := o.WriteObject(res); err != nil {
 		return err
-	} else {
-		o.WriteBody(resp.Body())
 	}
-	// spec
-	if resp, err := http.Delete(url + "/resources/spec"); err != nil {
-		return err
-	} else {
-		o.WriteBody(resp.Body())
+	// vpc, securityGroup, sshKey, image, spec
+	for _, kind := range []string{client.RESOURCE_VNET, client.RESOURCE_SECURITY_GROUP, client.RESOURCE_SSHKEY, client.RESOURCE_IMAGE, client.RESOURCE_SPEC} {
+		if res, err := tumblebug.DeleteResource(o.Namespace, kind, ""); err != nil {
+			return err
+		} else if err := o.WriteObject(res); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+// returns "kind/name" of MCISs and MCIRs of the namespace (kinds failed to list are reported and skipped)
+func (o *CleanOptions) listMCIR(tumblebug *client.TumblebugClient) []string {
+	objects := []string{}
+	for _, l := range []struct {
+		kind string
+		list func(string) (app.ListObject, error)
+	}{
+		{"mcis", func(ns string) (app.ListObject, error) { return tumblebug.ListMCIS(ns) }},
+		{client.RESOURCE_VNET, func(ns string) (app.ListObject, error) { return tumblebug.ListVNets(ns) }},
+		{client.RESOURCE_SECURITY_GROUP, func(ns string) (app.ListObject, error) { return tumblebug.ListSecurityGroups(ns) }},
+		{client.RESOURCE_SSHKEY, func(ns string) (app.ListObject, error) { return tumblebug.ListSshKeys(ns) }},
+		{client.RESOURCE_IMAGE, func(ns string) (app.ListObject, error) { return tumblebug.ListImages(ns) }},
+		{client.RESOURCE_SPEC, func(ns string) (app.ListObject, error) { return tumblebug.ListSpecs(ns) }},
+	} {
+		objs, err := l.list(o.Namespace)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to list %s objects, they are not listed below (cause=%v)\n", l.kind, err)
+			continue
+		}
+		for _, item := range objs.GetItems() {
+			if n, ok := item.(app.NamedObject); ok {
+				objects = append(objects, n.GetKind()+"/"+n.GetName())
+			}
+		}
+	}
+	return objects
+}
+
 // returns a cobra command
 func NewCommandClean(options *app.Options) *cobra.Command {
 
@@ -99,5 +117,8 @@ func NewCommandClean(options *app.Options) *cobra.Command {
 		},
 	}
 
+	o.AddDryRunFlag(cmd)
+	o.AddYesFlag(cmd)
+
 	return cmd
 }
@@ -6,14 +6,23 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/itnpeople/cbctl/app"
+	"github.com/itnpeople/cbctl/client"
+	"github.com/itnpeople/cbctl/cmd/apply"
+	"github.com/itnpeople/cbctl/cmd/bootstrap"
 	"github.com/itnpeople/cbctl/cmd/clean"
 	"github.com/itnpeople/cbctl/cmd/config"
 	"github.com/itnpeople/cbctl/cmd/create"
 	"github.com/itnpeople/cbctl/cmd/delete"
+	"github.com/itnpeople/cbctl/cmd/describe"
 	"github.com/itnpeople/cbctl/cmd/get"
 	"github.com/itnpeople/cbctl/cmd/get-key"
 	"github.com/itnpeople/cbctl/cmd/plugin"
+	"github.com/itnpeople/cbctl/cmd/profile"
+	"github.com/itnpeople/cbctl/cmd/scale"
 	"github.com/itnpeople/cbctl/cmd/update-kubeconfig"
+	"github.com/itnpeople/cbctl/cmd/validate"
+	"github.com/itnpeople/cbctl/cmd/verify"
+	"github.com/itnpeople/cbctl/cmd/wait"
 )
 
 func Execute() {
@@ -46,13 +55,38 @@ func NewRootCommand() *cobra.Command {
 		Run: func(cmd *cobra.Command, args []string) {
 			cmd.Help()
 		},
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.ValidateDryRun(); err != nil {
+				return err
+			}
+			client.Verbosity = o.Verbosity
+			if cmd.Flags().Changed("request-timeout") {
+				client.Overrides.Timeout = &o.Request.Timeout
+			}
+			if cmd.Flags().Changed("retries") {
+				client.Overrides.Retries = &o.Request.Retries
+			}
+			if cmd.Flags().Changed("retry-backoff") {
+				client.Overrides.RetryBackoff = &o.Request.RetryBackoff
+			}
+			if o.IsDryRun() {
+				client.DryRun = func(req *client.Request) {
+					app.ValidateError(cmd, o.WriteObject(req))
+				}
+			}
+			return o.ValidateOutput()
+		},
 	}
 	// Persistent Flags
 	cmds.PersistentFlags().StringVarP(&o.ConfigFile, "config", "c", "", "Configuration file path")
-	cmds.PersistentFlags().StringVarP(&o.Output, "output", "o", app.OUTPUT_YAML, "Output format(yaml/json)")
+	cmds.PersistentFlags().StringVarP(&o.Output, "output", "o", app.OUTPUT_TABLE, "Output format(table/wide/yaml/json/name/jsonpath=.../go-template=.../go-template-file=...)")
 	cmds.PersistentFlags().StringVarP(&o.Filename, "file", "f", "", "Filename")
 	cmds.PersistentFlags().StringVarP(&o.Namespace, "namespace", "n", "", "Cloud-barista namespace")
 	cmds.PersistentFlags().StringVar(&o.Name, "name", "", "Name")
+	cmds.PersistentFlags().IntVarP(&o.Verbosity, "v", "v", 0, "Log level (1: retries, 6: requests, 7: headers, 8: bodies)")
+	cmds.PersistentFlags().DurationVar(&o.Request.Timeout, "request-timeout", client.DEFAULT_REQUEST_TIMEOUT, "Timeout of a request (0: no timeout, default of a context)")
+	cmds.PersistentFlags().IntVar(&o.Request.Retries, "retries", 0, "Retries of idempotent requests (GET, DELETE) and connection errors (default of a context)")
+	cmds.PersistentFlags().DurationVar(&o.Request.RetryBackoff, "retry-backoff", client.DEFAULT_RETRY_BACKOFF, "Initial wait time between retries (exponential, default of a context)")
 
 	// initialize config file
 	if err := app.OnConfigInitialize(o.ConfigFile); err != nil {
@@ -72,11 +106,19 @@ func NewRootCommand() *cobra.Command {
 	cmds.AddCommand(get.NewCommandGet(&o.Options))                           // cbctl get
 	cmds.AddCommand(create.NewCommandCreate(&o.Options))                     // cbctl create
 	cmds.AddCommand(delete.NewCommandDelete(&o.Options))                     // cbctl delete
+	cmds.AddCommand(apply.NewCommandApply(&o.Options))                       // cbctl apply
 	cmds.AddCommand(config.NewCommandConfig(&o.Options))                     // cbctl config
 	cmds.AddCommand(updatekubeconfig.NewCommandUpdateKubeconfig(&o.Options)) // cbctl update-kubeconfig
 	cmds.AddCommand(getkey.NewCommandGetKey(&o.Options))                     // cbctl get-key
 	cmds.AddCommand(plugin.NewCommandPlugin(&o.Options))                     // cbctl plugin
 	cmds.AddCommand(clean.NewCommandClean(&o.Options))                       // cbctl clean
+	cmds.AddCommand(wait.NewCommandWait(&o.Options))                         // cbctl wait
+	cmds.AddCommand(bootstrap.NewCommandBootstrap(&o.Options))               // cbctl bootstrap
+	cmds.AddCommand(verify.NewCommandVerify(&o.Options))                     // cbctl verify
+	cmds.AddCommand(describe.NewCommandDescribe(&o.Options))                 // cbctl describe
+	cmds.AddCommand(scale.NewCommandScale(&o.Options))                       // cbctl scale
+	cmds.AddCommand(profile.NewCommandProfile(&o.Options))                   // cbctl profile
+	cmds.AddCommand(validate.NewCommandValidate(&o.Options))                 // cbctl validate
 
 	// execute plugin
 	if len(os.Args) > 0 {
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/itnpeople/cbctl/app"
+	"github.com/itnpeople/cbctl/client"
+)
+
+// flags of a service authentication
+type authFlags struct {
+	Type     string
+	Username string
+	Password string
+	Token    string
+	Headers  []string
+}
+
+func (f *authFlags) isEmpty() bool {
+	return f.Type == "" && f.Username == "" && f.Password == "" && f.Token == "" && len(f.Headers) == 0
+}
+
+// adds authentication flags of services (eg. --mcks-auth-type, --spider-token)
+func addAuthFlags(c *cobra.Command) map[string]*authFlags {
+
+	flags := map[string]*authFlags{}
+	for _, svc := range []string{client.SERVICE_MCKS, client.SERVICE_SPIDER, client.SERVICE_TUMBLEBUG} {
+		f := &authFlags{}
+		c.Flags().StringVar(&f.Type, svc+"-auth-type", "", fmt.Sprintf("Authentication type of %s (none, basic, bearer)", svc))
+		c.Flags().StringVar(&f.Username, svc+"-username", "", fmt.Sprintf("Username of %s (basic)", svc))
+		c.Flags().StringVar(&f.Password, svc+"-password", "", fmt.Sprintf("Password reference of %s (basic, env:NAME or file:PATH)", svc))
+		c.Flags().StringVar(&f.Token, svc+"-token", "", fmt.Sprintf("Token reference of %s (bearer, env:NAME or file:PATH)", svc))
+		c.Flags().StringArrayVar(&f.Headers, svc+"-header", []string{}, fmt.Sprintf("Custom header of %s (KEY=VALUE, VALUE can be env:NAME or file:PATH and must be a reference for credential headers eg. Authorization, X-API-Key, an empty VALUE removes the header)", svc))
+		flags[svc] = f
+	}
+	return flags
+}
+
+// merges authentication flags into a context
+func applyAuthFlags(ctx *app.ConfigContext, flags map[string]*authFlags) error {
+
+	for svc, f := range flags {
+		if f.isEmpty() {
+			continue
+		}
+		auth := &app.AuthConfig{}
+		if ctx.GetAuth(svc) != nil {
+			*auth = *ctx.GetAuth(svc)
+		}
+
+		// type (inferred from a token or a password)
+		switch {
+		case f.Type != "":
+			auth.Type = f.Type
+		case f.Token != "":
+			auth.Type = app.AUTH_BEARER
+		case f.Password != "" || f.Username != "":
+			auth.Type = app.AUTH_BASIC
+		case auth.Type == "":
+			auth.Type = app.AUTH_NONE
+		}
+		switch auth.Type {
+		case app.AUTH_BASIC:
+			auth.Token = ""
+		case app.AUTH_BEARER:
+			auth.Username, auth.Password = "", ""
+		case app.AUTH_NONE:
+			auth.Username, auth.Password, auth.Token = "", "", ""
+		}
+		if f.Username != "" {
+			auth.Username = f.Username
+		}
+		if f.Password != "" {
+			auth.Password = f.Password
+		}
+		if f.Token != "" {
+			auth.Token = f.Token
+		}
+
+		// headers
+		headers := map[string]string{}
+		for k, v := range auth.Headers {
+			headers[k] = v
+		}
+		for _, h := range f.Headers {
+			kv := strings.SplitN(h, "=", 2)
+			if len(kv) != 2 || kv[0] == "" {
+				return fmt.Errorf("Invalid header (service=%s, header=%s, allowed=KEY=VALUE)", svc, h)
+			}
+			if kv[1] == "" {
+				delete(headers, kv[0])
+			} else {
+				headers[kv[0]] = kv[1]
+			}
+		}
+		auth.Headers = nil
+		if len(headers) > 0 {
+			auth.Headers = headers
+		}
+
+		if err := auth.Validate(); err != nil {
+			return fmt.Errorf("%s: %v", svc, err)
+		}
+		ctx.Service(svc).Auth = auth
+	}
+	return nil
+}
@@ -16,6 +16,7 @@ type ConfigOptions struct {
 	Url_mcks      string
 	Url_tumbelbug string
 	Url_spider    string
+	Protected     bool
 }
 
 func (o *ConfigOptions) writeYaml(in interface{}) {
@@ -43,6 +44,8 @@ func NewCommandConfig(options *app.Options) *cobra.Command {
 	}
 
 	// add-context
+	var authC map[string]*authFlags
+	var tlsC map[string]*tlsFlags
 	cmdC := &cobra.Command{
 		Use:                   "add-context  (NAME | --name NAME) [options]",
 		Short:                 "Add a context",
@@ -59,7 +62,7 @@ func NewCommandConfig(options *app.Options) *cobra.Command {
 				if _, ok := app.Config.Contexts[o.Name]; ok {
 					return fmt.Errorf("The context '%s' is alreaday exist", o.Name)
 				} else {
-					app.Config.Contexts[o.Name] = &app.ConfigContext{
+					ctx := &app.ConfigContext{
 						Name:      o.Name,
 						Namespace: o.Namespace,
 						Urls: struct {
@@ -67,7 +70,15 @@ func NewCommandConfig(options *app.Options) *cobra.Command {
 							Spider    string "yaml:\"spider\""
 							Tumblebug string "yaml:\"tumblebug\""
 						}{MCKS: o.Url_mcks, Spider: o.Url_spider, Tumblebug: o.Url_tumbelbug},
+						Protected: o.Protected,
 					}
+					if err := applyAuthFlags(ctx, authC); err != nil {
+						return err
+					}
+					if err := applyTLSFlags(c, ctx, tlsC); err != nil {
+						return err
+					}
+					app.Config.Contexts[o.Name] = ctx
 				}
 				app.Config.WriteConfig()
 				o.writeYaml(app.Config)
@@ -78,6 +89,9 @@ func NewCommandConfig(options *app.Options) *cobra.Command {
 	cmdC.Flags().StringVarP(&o.Url_mcks, "mcks", "", "", "MCKS endpoint URL (http://localhost:1470/mcks)")
 	cmdC.Flags().StringVarP(&o.Url_tumbelbug, "tumblebug", "", "http://localhost:1323/tumblebug", "Tumblebug endpoint URL")
 	cmdC.Flags().StringVarP(&o.Url_spider, "spider", "", "http://localhost:1024/spider", "Spider endpoint URL")
+	cmdC.Flags().BoolVar(&o.Protected, "protected", false, "Require typing a namespace name to confirm destructive commands")
+	authC = addAuthFlags(cmdC)
+	tlsC = addTLSFlags(cmdC)
 	cmds.AddCommand(cmdC)
 
 	// view
@@ -116,6 +130,8 @@ func NewCommandConfig(options *app.Options) *cobra.Command {
 	})
 
 	// set context
+	var authS map[string]*authFlags
+	var tlsS map[string]*tlsFlags
 	cmdS := &cobra.Command{
 		Use:                   "set-context  (NAME | --name NAME) [options]",
 		Short:                 "Set a context",
@@ -139,6 +155,16 @@ func NewCommandConfig(options *app.Options) *cobra.Command {
 					if o.Url_spider != "" {
 						app.Config.Contexts[o.Name].Urls.Spider = o.Url_spider
 					}
+					if c.Flags().Changed("protected") {
+						app.Config.Contexts[o.Name].Protected = o.Protected
+					}
+					if err := applyAuthFlags(app.Config.Contexts[o.Name], authS); err != nil {
+						return err
+					}
+					if err := applyTLSFlags(c, app.Config.Contexts[o.Name], tlsS); err != nil {
+						return err
+					}
+					app.Config.WriteConfig()
 					o.writeYaml(app.Config.Contexts[o.Name])
 				} else {
 					o.Println("Not found a context (name=%s)", o.Name)
@@ -148,8 +174,11 @@ func NewCommandConfig(options *app.Options) *cobra.Command {
 		},
 	}
 	cmdS.Flags().StringVarP(&o.Url_mcks, "mcks", "", "", "MCKS endpoint URL (http://localhost:1470/mcks)")
-	cmdS.Flags().StringVarP(&o.Url_tumbelbug, "tumblebug", "", "http://localhost:1323/tumblebug", "Tumblebug endpoint URL")
-	cmdS.Flags().StringVarP(&o.Url_spider, "spider", "", "http://localhost:1024/spider", "Spider endpoint URL")
+	cmdS.Flags().StringVarP(&o.Url_tumbelbug, "tumblebug", "", "", "Tumblebug endpoint URL (http://localhost:1323/tumblebug)")
+	cmdS.Flags().StringVarP(&o.Url_spider, "spider", "", "", "Spider endpoint URL (http://localhost:1024/spider)")
+	cmdS.Flags().BoolVar(&o.Protected, "protected", false, "Require typing a namespace name to confirm destructive commands (--protected=false to unset)")
+	authS = addAuthFlags(cmdS)
+	tlsS = addTLSFlags(cmdS)
 	cmds.AddCommand(cmdS)
 
 	// current-context (get/set)
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/itnpeople/cbctl/app"
+	"github.com/itnpeople/cbctl/client"
+)
+
+// flags of a service TLS
+type tlsFlags struct {
+	CA                 string
+	Cert               string
+	Key                string
+	InsecureSkipVerify bool
+}
+
+// adds TLS flags of services (eg. --mcks-ca, --spider-insecure-skip-verify)
+func addTLSFlags(c *cobra.Command) map[string]*tlsFlags {
+
+	flags := map[string]*tlsFlags{}
+	for _, svc := range []string{client.SERVICE_MCKS, client.SERVICE_SPIDER, client.SERVICE_TUMBLEBUG} {
+		f := &tlsFlags{}
+		c.Flags().StringVar(&f.CA, svc+"-ca", "", fmt.Sprintf("CA bundle file of %s (an empty value removes)", svc))
+		c.Flags().StringVar(&f.Cert, svc+"-client-cert", "", fmt.Sprintf("Client certificate file of %s (mTLS)", svc))
+		c.Flags().StringVar(&f.Key, svc+"-client-key", "", fmt.Sprintf("Client key file of %s (mTLS)", svc))
+		c.Flags().BoolVar(&f.InsecureSkipVerify, svc+"-insecure-skip-verify", false, fmt.Sprintf("Skip verifying a server certificate of %s (insecure)", svc))
+		flags[svc] = f
+	}
+	return flags
+}
+
+// merges changed TLS flags into a context
+func applyTLSFlags(c *cobra.Command, ctx *app.ConfigContext, flags map[string]*tlsFlags) error {
+
+	for svc, f := range flags {
+		conf := &app.TLSConfig{}
+		if ctx.GetTLS(svc) != nil {
+			*conf = *ctx.GetTLS(svc)
+		}
+		changed := false
+		if c.Flags().Changed(svc + "-ca") {
+			conf.CA, changed = f.CA, true
+		}
+		if c.Flags().Changed(svc+"-client-cert") || c.Flags().Changed(svc+"-client-key") {
+			conf.Cert, conf.Key, changed = f.Cert, f.Key, true
+		}
+		if c.Flags().Changed(svc + "-insecure-skip-verify") {
+			conf.InsecureSkipVerify, changed = f.InsecureSkipVerify, true
+		}
+		if !changed {
+			continue
+		}
+		if err := conf.Validate(); err != nil {
+			return fmt.Errorf("%s: %v", svc, err)
+		}
+		if *conf == (app.TLSConfig{}) {
+			conf = nil
+		}
+		ctx.Service(svc).TLS = conf
+	}
+	return nil
+}
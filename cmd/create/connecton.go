@@ -3,10 +3,12 @@ package create
 import (
 	"fmt"
 
-	"github.com/go-resty/resty/v2"
 	"github.com/spf13/cobra"
 
 	"github.com/itnpeople/cbctl/app"
+	"github.com/itnpeople/cbctl/client"
+	"github.com/itnpeople/cbctl/cmd/verify"
+	"github.com/itnpeople/cbctl/schema"
 )
 
 // a struct to support command
@@ -15,6 +17,7 @@ type ConnectionOptions struct {
 	CSP        string
 	Credential string
 	Region     string
+	Verify     bool
 }
 
 // returns a cobra command
@@ -45,7 +48,7 @@ func NewCommandConnection(options *app.Options) *cobra.Command {
 						return fmt.Errorf("Region name is required.")
 					}
 				}
-				if out, err := app.GetBody(o, `{
+				if out, err := schema.GetBody(o, "connection", `{
 					"ConfigName"     : "{{ .Name }}",
 					"ProviderName"   : "{{ .CSP | ToUpper }}", 
 					"DriverName"     : "{{ .CSP }}-driver-v1.0", 
@@ -54,11 +57,15 @@ func NewCommandConnection(options *app.Options) *cobra.Command {
 				}`); err != nil {
 					return err
 				} else {
-					url := fmt.Sprintf("%s/connectionconfig", app.Config.GetCurrentContext().Urls.Spider)
-					if resp, err := resty.New().SetDisableWarn(true).R().SetHeader("content-type", "application/json").SetBody(out).Post(url); err != nil {
+					if res, err := client.NewSpider(app.Config.GetCurrentContext()).CreateConnection(out); err != nil {
 						return err
 					} else {
-						o.WriteBody(resp.Body())
+						if err := o.WriteObject(res); err != nil {
+							return err
+						}
+						if o.Verify && !o.IsDryRun() {
+							return verify.VerifyConnection(o.Options, res.ConfigName)
+						}
 					}
 				}
 				return nil
@@ -68,6 +75,7 @@ func NewCommandConnection(options *app.Options) *cobra.Command {
 	cmd.Flags().StringVar(&o.CSP, "csp", "", "Cloud service provider (aws, gcp, azure, alibaba, tencent, ibm, openstack, cloudit)")
 	cmd.Flags().StringVar(&o.Region, "region", "", "Region name")
 	cmd.Flags().StringVar(&o.Credential, "credential", "", "Credential name")
+	cmd.Flags().BoolVar(&o.Verify, "verify", false, "Verify the connection with read-only calls to the CSP after creation")
 
 	return cmd
 }
@@ -2,11 +2,13 @@ package create
 
 import (
 	"fmt"
+	"strings"
 
-	"github.com/go-resty/resty/v2"
 	"github.com/spf13/cobra"
 
 	"github.com/itnpeople/cbctl/app"
+	"github.com/itnpeople/cbctl/client"
+	"github.com/itnpeople/cbctl/schema"
 	"github.com/itnpeople/cbctl/utils"
 )
 
@@ -23,6 +25,15 @@ type CreateClusterOptions struct {
 		Count      int
 		Spec       string
 	}
+	ControlPlaneFlags []string // --control-plane (repeatable)
+	WorkerFlags       []string // --worker (repeatable)
+	ControlPlanePools []app.NodePool
+	WorkerPools       []app.NodePool
+	Label             string
+	Description       string
+	Kubernetes        KubernetesConfig
+	Profile           string
+	profile           *app.ClusterProfile
 }
 
 // validates
@@ -34,38 +45,137 @@ func (o *CreateClusterOptions) Validate() error {
 	if o.Filename == "" && o.Name == "" {
 		return fmt.Errorf("Name is required.")
 	}
+	if o.Filename != "" && o.Profile != "" {
+		return fmt.Errorf("A profile can't be used with a file (-f)")
+	}
+
+	// node-pools (--control-plane, --worker or --control-plane-*, --worker-* or a profile)
+	profile := &app.ClusterProfile{}
+	if o.profile != nil {
+		profile = o.profile
+	}
+	var err error
+	if o.ControlPlanePools, err = nodePools("control-plane", o.ControlPlaneFlags, app.NodePool(o.ControlPlane), profile.ControlPlane); err != nil {
+		return err
+	}
+	if o.WorkerPools, err = nodePools("worker", o.WorkerFlags, app.NodePool(o.Worker), profile.Worker); err != nil {
+		return err
+	}
+	if o.Filename == "" {
+		return o.Kubernetes.Validate()
+	}
+	return nil
+}
+
+// loads a profile and sets fields of which flags are not changed
+func (o *CreateClusterOptions) LoadProfile(c *cobra.Command) error {
+	if o.Profile == "" {
+		return nil
+	}
+	profile, err := app.LoadProfile(o.Profile)
+	if err != nil {
+		return err
+	}
+	if profile.ControlPlane, err = overridePools(c, "control-plane", profile.ControlPlane, app.NodePool(o.ControlPlane)); err != nil {
+		return err
+	}
+	if profile.Worker, err = overridePools(c, "worker", profile.Worker, app.NodePool(o.Worker)); err != nil {
+		return err
+	}
+	o.profile = profile
+
+	k := profile.Config.Kubernetes
+	for _, f := range []struct {
+		flag  string
+		field *string
+		value string
+	}{
+		{"label", &o.Label, profile.Label},
+		{"description", &o.Description, profile.Description},
+		{"kubernetes-version", &o.Kubernetes.Version, k.Version},
+		{"network-cni", &o.Kubernetes.NetworkCni, k.NetworkCni},
+		{"pod-cidr", &o.Kubernetes.PodCidr, k.PodCidr},
+		{"service-cidr", &o.Kubernetes.ServiceCidr, k.ServiceCidr},
+		{"service-dns-domain", &o.Kubernetes.ServiceDnsDomain, k.ServiceDnsDomain},
+	} {
+		if !c.Flags().Changed(f.flag) && f.value != "" {
+			*f.field = f.value
+		}
+	}
 	return nil
 }
 
+// overrides a node-pool of a profile by each of changed single flags (--*-connection, --*-count, --*-spec)
+func overridePools(c *cobra.Command, flag string, pools []app.NodePool, single app.NodePool) ([]app.NodePool, error) {
+	changed := func(name string) bool {
+		return c.Flags().Changed(flag + "-" + name)
+	}
+	if !changed("connection") && !changed("count") && !changed("spec") {
+		return pools, nil
+	}
+	if len(pools) > 1 {
+		return nil, fmt.Errorf("--%s-connection, --%s-count and --%s-spec can't override %d node-pools of a profile, use --%s instead", flag, flag, flag, len(pools), flag)
+	}
+	pool := single
+	if len(pools) == 1 {
+		pool = pools[0]
+	}
+	if changed("connection") {
+		pool.Connection = single.Connection
+	}
+	if changed("count") {
+		pool.Count = single.Count
+	}
+	if changed("spec") {
+		pool.Spec = single.Spec
+	}
+	return []app.NodePool{pool}, nil
+}
+
+// returns node-pools of repeatable flags, node-pools of a profile (overridden by single flags) or a node-pool of single flags (--*-connection, --*-count, --*-spec)
+func nodePools(flag string, values []string, single app.NodePool, profile []app.NodePool) ([]app.NodePool, error) {
+	if len(values) == 0 {
+		if len(profile) > 0 {
+			return profile, nil
+		}
+		return []app.NodePool{single}, nil
+	}
+	if single.Connection != "" || single.Spec != "" {
+		return nil, fmt.Errorf("--%s can't be used with --%s-connection and --%s-spec", flag, flag, flag)
+	}
+	return ParseNodePools(flag, values)
+}
+
 func (o *CreateClusterOptions) Run() error {
 
 	// execute
-	if out, err := app.GetBody(o, `{
+	if out, err := schema.GetBody(o, "cluster", `{
 		"name": "{{.Name}}",
-		"label": "",
-		"description": "",
-		"controlPlane": [
-			{ "connection": "{{.ControlPlane.Connection}}", "count": {{.ControlPlane.Count}}, "spec": "{{.ControlPlane.Spec}}" }
+		"label": "{{.Label | EscapeJSON}}",
+		"description": "{{.Description | EscapeJSON}}",
+		"controlPlane": [{{ range $i, $p := .ControlPlanePools }}{{ if $i }},{{ end }}
+			{ "connection": "{{$p.Connection}}", "count": {{$p.Count}}, "spec": "{{$p.Spec}}" }{{ end }}
 		],
-		"worker": [
-			{ "connection": "{{.Worker.Connection}}", "count": {{.Worker.Count}}, "spec": "{{.Worker.Spec}}" }
+		"worker": [{{ range $i, $p := .WorkerPools }}{{ if $i }},{{ end }}
+			{ "connection": "{{$p.Connection}}", "count": {{$p.Count}}, "spec": "{{$p.Spec}}" }{{ end }}
 			],
 			"config": {
 				"kubernetes": {
-					"networkCni": "canal",
-					"podCidr": "10.244.0.0/16",
-					"serviceCidr": "10.96.0.0/12",
-					"serviceDnsDomain": "cluster.local"
+					{{- if .Kubernetes.Version }}
+					"version": "{{.Kubernetes.Version}}",{{ end }}
+					"networkCni": "{{.Kubernetes.NetworkCni}}",
+					"podCidr": "{{.Kubernetes.PodCidr}}",
+					"serviceCidr": "{{.Kubernetes.ServiceCidr}}",
+					"serviceDnsDomain": "{{.Kubernetes.ServiceDnsDomain}}"
 				}
 			}
 		}`); err != nil {
 		return err
 	} else {
-		url := fmt.Sprintf("%s/ns/%s/clusters", app.Config.GetCurrentContext().Urls.MCKS, o.Namespace)
-		if resp, err := resty.New().SetDisableWarn(true).R().SetHeader("content-type", "application/json").SetBody(out).Post(url); err != nil {
+		if res, err := client.NewMCKS(app.Config.GetCurrentContext()).CreateCluster(o.Namespace, out); err != nil {
+			return err
+		} else if err := o.WriteObject(res); err != nil {
 			return err
-		} else {
-			o.WriteBody(resp.Body())
 		}
 	}
 	return nil
@@ -80,6 +190,8 @@ type CreateNodeOptions struct {
 		Count      int
 		Spec       string
 	}
+	WorkerFlags []string // --worker (repeatable)
+	WorkerPools []app.NodePool
 }
 
 // validates
@@ -94,23 +206,26 @@ func (o *CreateNodeOptions) Validate() error {
 	if o.Filename == "" && o.Name == "" {
 		return fmt.Errorf("Name is required.")
 	}
+	var err error
+	if o.WorkerPools, err = nodePools("worker", o.WorkerFlags, app.NodePool(o.Worker), nil); err != nil {
+		return err
+	}
 	return nil
 }
 
 func (o *CreateNodeOptions) Run() error {
 
 	// exute
-	if out, err := app.GetBody(o, `
-		{"worker": [
-			{ "connection": "{{.Worker.Connection}}", "count": {{.Worker.Count}}, "spec": "{{.Worker.Spec}}" }
+	if out, err := schema.GetBody(o, "node", `
+		{"worker": [{{ range $i, $p := .WorkerPools }}{{ if $i }},{{ end }}
+			{ "connection": "{{$p.Connection}}", "count": {{$p.Count}}, "spec": "{{$p.Spec}}" }{{ end }}
 		]}`); err != nil {
 		return err
 	} else {
-		url := fmt.Sprintf("%s/ns/%s/clusters/%s/nodes", app.Config.GetCurrentContext().Urls.MCKS, o.Namespace, o.clusterName)
-		if resp, err := resty.New().SetDisableWarn(true).R().SetHeader("content-type", "application/json").SetBody(out).Post(url); err != nil {
+		if res, err := client.NewMCKS(app.Config.GetCurrentContext()).CreateNode(o.Namespace, o.clusterName, out); err != nil {
+			return err
+		} else if err := o.WriteObject(res); err != nil {
 			return err
-		} else {
-			o.WriteBody(resp.Body())
 		}
 	}
 	return nil
@@ -139,6 +254,7 @@ func NewCommandCreate(options *app.Options) *cobra.Command {
 		DisableFlagsInUseLine: true,
 		Args:                  app.BindCommandArgs(&oCluster.Name),
 		Run: func(c *cobra.Command, args []string) {
+			app.ValidateError(c, oCluster.LoadProfile(c))
 			app.ValidateError(c, oCluster.Validate())
 			app.ValidateError(c, oCluster.Run())
 		},
@@ -149,6 +265,16 @@ func NewCommandCreate(options *app.Options) *cobra.Command {
 	cmdC.Flags().StringVar(&oCluster.Worker.Connection, "worker-connection", "", "Connection name of wroker nodes")
 	cmdC.Flags().IntVar(&oCluster.Worker.Count, "worker-count", 1, "Count of wroker nodes")
 	cmdC.Flags().StringVar(&oCluster.Worker.Spec, "worker-spec", "", "Spec. of wroker nodes")
+	cmdC.Flags().StringArrayVar(&oCluster.ControlPlaneFlags, "control-plane", []string{}, "Control-plane nodes, repeatable (eg. connection=config-aws-tokyo,count=3,spec=t2.medium)")
+	cmdC.Flags().StringArrayVar(&oCluster.WorkerFlags, "worker", []string{}, "Worker nodes, repeatable (eg. connection=config-gcp-tokyo,count=2,spec=e2-highcpu-4)")
+	cmdC.Flags().StringVar(&oCluster.Profile, "profile", "", "Cluster profile of ~/.cbctl/profiles (flags override fields of the profile)")
+	cmdC.Flags().StringVar(&oCluster.Label, "label", "", "Label of cluster")
+	cmdC.Flags().StringVar(&oCluster.Description, "description", "", "Description of cluster")
+	cmdC.Flags().StringVar(&oCluster.Kubernetes.Version, "kubernetes-version", "", "Kubernetes version (default of MCKS if empty, eg. 1.23.13)")
+	cmdC.Flags().StringVar(&oCluster.Kubernetes.NetworkCni, "network-cni", DEFAULT_NETWORK_CNI, fmt.Sprintf("Network CNI (%s)", strings.Join(NETWORK_CNIS, ", ")))
+	cmdC.Flags().StringVar(&oCluster.Kubernetes.PodCidr, "pod-cidr", DEFAULT_POD_CIDR, "CIDR of pods")
+	cmdC.Flags().StringVar(&oCluster.Kubernetes.ServiceCidr, "service-cidr", DEFAULT_SERVICE_CIDR, "CIDR of services")
+	cmdC.Flags().StringVar(&oCluster.Kubernetes.ServiceDnsDomain, "service-dns-domain", DEFAULT_SERVICE_DNS_DOMAIN, "DNS domain of services")
 	cmds.AddCommand(cmdC)
 
 	oNode := &CreateNodeOptions{
@@ -169,8 +295,11 @@ func NewCommandCreate(options *app.Options) *cobra.Command {
 	cmdN.Flags().StringVar(&oNode.Worker.Connection, "worker-connection", "", "Connection name of wroker nodes")
 	cmdN.Flags().IntVar(&oNode.Worker.Count, "worker-count", 1, "Count of wroker nodes")
 	cmdN.Flags().StringVar(&oNode.Worker.Spec, "worker-spec", "", "Spec. of wroker nodes")
+	cmdN.Flags().StringArrayVar(&oNode.WorkerFlags, "worker", []string{}, "Worker nodes, repeatable (eg. connection=config-gcp-tokyo,count=2,spec=e2-highcpu-4)")
 	cmds.AddCommand(cmdN)
 
+	options.AddDryRunFlag(cmds)
+
 	cmds.AddCommand(NewCommandDriver(options))     // cbctl crate driver
 	cmds.AddCommand(NewCommandRegion(options))     // cbctl create region
 	cmds.AddCommand(NewCommandCredential(options)) // cbctl create credential
@@ -0,0 +1,48 @@
+package create
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/itnpeople/cbctl/app"
+)
+
+func TestOverridePools(t *testing.T) {
+	profile := []app.NodePool{{Connection: "config-aws", Count: 2, Spec: "t2.medium"}}
+	tests := []struct {
+		name  string
+		args  []string
+		pools []app.NodePool
+		want  []app.NodePool
+	}{
+		{"no flags", []string{}, profile, profile},
+		{"count", []string{"--worker-count=5"}, profile, []app.NodePool{{Connection: "config-aws", Count: 5, Spec: "t2.medium"}}},
+		{"spec", []string{"--worker-spec=t2.large"}, profile, []app.NodePool{{Connection: "config-aws", Count: 2, Spec: "t2.large"}}},
+		{"connection and count", []string{"--worker-connection=config-gcp", "--worker-count=1"}, profile, []app.NodePool{{Connection: "config-gcp", Count: 1, Spec: "t2.medium"}}},
+		{"a profile without pools", []string{"--worker-count=3"}, nil, []app.NodePool{{Connection: "", Count: 3, Spec: ""}}},
+	}
+	for _, tt := range tests {
+		c := &cobra.Command{}
+		single := app.NodePool{}
+		c.Flags().StringVar(&single.Connection, "worker-connection", "", "")
+		c.Flags().IntVar(&single.Count, "worker-count", 1, "")
+		c.Flags().StringVar(&single.Spec, "worker-spec", "", "")
+		if err := c.Flags().Parse(tt.args); err != nil {
+			t.Fatal(err)
+		}
+		got, err := overridePools(c, "worker", tt.pools, single)
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: overridePools() = %v, %v, want %v", tt.name, got, err, tt.want)
+		}
+	}
+
+	// multiple node-pools
+	c := &cobra.Command{}
+	c.Flags().Int("worker-count", 1, "")
+	c.Flags().Parse([]string{"--worker-count=5"})
+	if _, err := overridePools(c, "worker", append(profile, profile...), app.NodePool{Count: 5}); err == nil {
+		t.Error("overridePools() of multiple node-pools expected an error")
+	}
+}
@@ -2,11 +2,13 @@ package create
 
 import (
 	"fmt"
+	"strings"
 
-	"github.com/go-resty/resty/v2"
 	"github.com/spf13/cobra"
 
 	"github.com/itnpeople/cbctl/app"
+	"github.com/itnpeople/cbctl/client"
+	"github.com/itnpeople/cbctl/schema"
 	"github.com/itnpeople/cbctl/utils"
 )
 
@@ -27,8 +29,32 @@ type CredentialOptions struct {
 	Password       string
 	DomainName     string
 	AutoToken      string
+	FromFile       string
+	Profile        string
+	Stdin          map[string]*bool // reads a secret from the standard-in (flag name : --*-stdin)
 }
 
+// a template of a request body
+const CREDENTIAL_TEMPLATE = `{
+	"CredentialName"   : "{{ .Name }}",
+	"ProviderName"     : "{{ .CSP | ToUpper }}",
+	"KeyValueInfoList" : [
+		{"Key" : "ClientId",         "Value" : "{{ .ClientID | EscapeJSON }}"},
+		{"Key" : "ClientSecret",     "Value" : "{{ .ClientSecret | EscapeJSON }}"},
+		{"Key" : "ClientEmail",      "Value" : "{{ .ClientEmail | EscapeJSON }}"},
+		{"Key" : "ProjectID",        "Value" : "{{ .ProjectID | EscapeJSON }}"},
+		{"Key" : "PrivateKey",       "Value" : "{{ .PrivateKey | EscapeJSON }}"},
+		{"Key" : "TenantId",         "Value" : "{{ .TenantId | EscapeJSON }}"},
+		{"Key" : "SubscriptionId",   "Value" : "{{ .SubscriptionId | EscapeJSON }}"},
+		{"Key" : "ApiKey",           "Value" : "{{ .ApiKey | EscapeJSON }}"},
+		{"Key" : "IdentityEndpoint", "Value" : "{{ .Endpoint | EscapeJSON }}"},
+		{"Key" : "Username",         "Value" : "{{ .Username | EscapeJSON }}"},
+		{"Key" : "Password",         "Value" : "{{ .Password | EscapeJSON }}"},
+		{"Key" : "DomainName",       "Value" : "{{ .DomainName | EscapeJSON }}"},
+		{"Key" : "AuthToken",        "Value" : "{{ .AutoToken | EscapeJSON }}"}
+	]
+}`
+
 // validates the provided options
 func (o *CredentialOptions) Validate() error {
 	o.Namespace = utils.NVL(o.Namespace, app.Config.GetCurrentContext().Namespace)
@@ -43,35 +69,45 @@ func (o *CredentialOptions) Validate() error {
 	if o.Name == "" {
 		return fmt.Errorf("Name is required.")
 	}
+	if o.FromFile != "" {
+		if err := o.LoadFile(o.FromFile, o.Profile); err != nil {
+			return err
+		}
+	} else if o.Profile != "" {
+		return fmt.Errorf("A profile requires a credential file (--from-file)")
+	}
+	if err := o.ReadSecrets(); err != nil {
+		return err
+	}
 	switch o.CSP {
-	case "aws", "alibaba", "tencet":
+	case "aws", "alibaba", "tencent":
 		if o.ClientID == "" || o.ClientSecret == "" {
-			return fmt.Errorf("Invalid credential flag (csp=%s, key=%s, secret=%s)", o.CSP, o.ClientID, o.ClientSecret)
+			return fmt.Errorf("Invalid credential flag (csp=%s, missing=%s)", o.CSP, missingFlags("secret-id", o.ClientID, "secret", o.ClientSecret))
 		}
 		break
 	case "gcp":
 		if o.ClientEmail == "" || o.ProjectID == "" || o.PrivateKey == "" {
-			return fmt.Errorf("Invalid credential flag (csp=%s, email=%s, project=%s, private-key=%s)", o.CSP, o.ClientEmail, o.ProjectID, o.PrivateKey)
+			return fmt.Errorf("Invalid credential flag (csp=%s, missing=%s)", o.CSP, missingFlags("client-email", o.ClientEmail, "project-id", o.ProjectID, "private-key", o.PrivateKey))
 		}
 		break
 	case "azure":
 		if o.ClientID == "" || o.ClientSecret == "" || o.TenantId == "" || o.SubscriptionId == "" {
-			return fmt.Errorf("Invalid credential flag (csp=%s, id=%s, secret=%s, tenant=%s, subscription=%s)", o.CSP, o.ClientID, o.ClientSecret, o.TenantId, o.SubscriptionId)
+			return fmt.Errorf("Invalid credential flag (csp=%s, missing=%s)", o.CSP, missingFlags("secret-id", o.ClientID, "secret", o.ClientSecret, "tenant", o.TenantId, "subscription", o.SubscriptionId))
 		}
 		break
 	case "ibm":
 		if o.ApiKey == "" {
-			return fmt.Errorf("Invalid credential flag (csp=%s, api-key=%s)", o.CSP, o.ApiKey)
+			return fmt.Errorf("Invalid credential flag (csp=%s, missing=%s)", o.CSP, missingFlags("api-key", o.ApiKey))
 		}
 		break
 	case "openstack":
 		if o.Endpoint == "" || o.Username == "" || o.Password == "" || o.DomainName == "" || o.ProjectID == "" {
-			return fmt.Errorf("Invalid credential flag (csp=%s, endpoint=%s, username=%s, password=%s, domain=%s, project-id=%s)", o.CSP, o.Endpoint, o.Username, o.Password, o.DomainName, o.ProjectID)
+			return fmt.Errorf("Invalid credential flag (csp=%s, missing=%s)", o.CSP, missingFlags("endpoint", o.Endpoint, "username", o.Username, "password", o.Password, "domain", o.DomainName, "project-id", o.ProjectID))
 		}
 		break
 	case "cloudit":
 		if o.Endpoint == "" || o.Username == "" || o.Password == "" || o.AutoToken == "" || o.TenantId == "" {
-			return fmt.Errorf("Invalid credential flag (csp=%s, endpoint=%s, username=%s, password=%s, token=%s, tenent=%s)", o.CSP, o.Endpoint, o.Username, o.Password, o.AutoToken, o.TenantId)
+			return fmt.Errorf("Invalid credential flag (csp=%s, missing=%s)", o.CSP, missingFlags("endpoint", o.Endpoint, "username", o.Username, "password", o.Password, "token", o.AutoToken, "tenant", o.TenantId))
 		}
 		break
 	default:
@@ -80,6 +116,22 @@ func (o *CredentialOptions) Validate() error {
 	return nil
 }
 
+// returns names of empty flags of flag-value pairs (values are not printed, they may be secrets)
+func missingFlags(pairs ...string) string {
+	flags := []string{}
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i+1] == "" {
+			flags = append(flags, "--"+pairs[i])
+		}
+	}
+	return strings.Join(flags, ",")
+}
+
+// returns a request body (a file or the template)
+func (o *CredentialOptions) Body() ([]byte, error) {
+	return schema.GetBody(o, "credential", CREDENTIAL_TEMPLATE)
+}
+
 // returns a cobra command
 func NewCommandCredential(options *app.Options) *cobra.Command {
 	o := &CredentialOptions{
@@ -95,32 +147,13 @@ func NewCommandCredential(options *app.Options) *cobra.Command {
 		Run: func(c *cobra.Command, args []string) {
 			app.ValidateError(c, o.Validate())
 			app.ValidateError(c, func() error {
-				if out, err := app.GetBody(o, `{
-					"CredentialName"   : "{{ .Name }}",
-					"ProviderName"     : "{{ .CSP | ToUpper }}",
-					"KeyValueInfoList" : [
-						{"Key" : "ClientId",         "Value" : "{{ .ClientID }}"},
-						{"Key" : "ClientSecret",     "Value" : "{{ .ClientSecret }}"},
-						{"Key" : "ClientEmail",      "Value" : "{{ .ClientEmail }}"},
-						{"Key" : "ProjectID",        "Value" : "{{ .ProjectID }}"},
-						{"Key" : "PrivateKey",       "Value" : "{{ .PrivateKey }}"},
-						{"Key" : "TenantId",         "Value" : "{{ .TenantId }}"},
-						{"Key" : "SubscriptionId",   "Value" : "{{ .SubscriptionId }}"},
-						{"Key" : "ApiKey",           "Value" : "{{ .ApiKey }}"},
-						{"Key" : "IdentityEndpoint", "Value" : "{{ .Endpoint }}"},
-						{"Key" : "Username",         "Value" : "{{ .Username }}"},
-						{"Key" : "Password",         "Value" : "{{ .Password }}"},
-						{"Key" : "DomainName",       "Value" : "{{ .DomainName }}"},
-						{"Key" : "AuthToken",        "Value" : "{{ .AutoToken }}"}
-					]
-				}`); err != nil {
+				if out, err := o.Body(); err != nil {
 					return err
 				} else {
-					url := fmt.Sprintf("%s/credential", app.Config.GetCurrentContext().Urls.Spider)
-					if resp, err := resty.New().SetDisableWarn(true).R().SetHeader("content-type", "application/json").SetBody(out).Post(url); err != nil {
+					if res, err := client.NewSpider(app.Config.GetCurrentContext()).CreateCredential(out); err != nil {
+						return err
+					} else if err := o.WriteObject(res.Masked()); err != nil {
 						return err
-					} else {
-						o.WriteBody(resp.Body())
 					}
 				}
 				return nil
@@ -129,8 +162,8 @@ func NewCommandCredential(options *app.Options) *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&o.CSP, "csp", "", "Cloud service provider (aws, gcp, azure, alibaba, tencent, ibm, openstack, cloudit)")
-	cmd.Flags().StringVar(&o.ClientID, "secret-id", "", "Key id (aws, azure, alibaba, tencent)")      // AWS, Azure, Alibaba, Tencet
-	cmd.Flags().StringVar(&o.ClientSecret, "secret", "", "Key secret (aws, azure, alibaba, tencent)") // AWS, Azure, Alibaba, Tencet
+	cmd.Flags().StringVar(&o.ClientID, "secret-id", "", "Key id (aws, azure, alibaba, tencent)")      // AWS, Azure, Alibaba, Tencent
+	cmd.Flags().StringVar(&o.ClientSecret, "secret", "", "Key secret (aws, azure, alibaba, tencent)") // AWS, Azure, Alibaba, Tencent
 	cmd.Flags().StringVar(&o.SubscriptionId, "subscription", "", "Subscription id (azure)")           // Azure (additional)
 	cmd.Flags().StringVar(&o.TenantId, "tenant", "", "Tenant id (azure, cloudit)")                    // Azure, Cloudit (additional)
 	cmd.Flags().StringVar(&o.ClientEmail, "client-email", "", "Client email (gcp)")                   // GCP
@@ -142,6 +175,12 @@ func NewCommandCredential(options *app.Options) *cobra.Command {
 	cmd.Flags().StringVar(&o.Password, "password", "", "Password (openstack, cloudit)")               // Openstack, Cloudit
 	cmd.Flags().StringVar(&o.DomainName, "domain", "", "Domain Name (openstack)")                     // Openstack
 	cmd.Flags().StringVar(&o.AutoToken, "token", "", "Auth Token (cloudit)")                          // Cloudit
+	cmd.Flags().StringVar(&o.FromFile, "from-file", "", "Native credential file of the CSP (eg. ~/.aws/credentials, a GCP service-account key)")
+	cmd.Flags().StringVar(&o.Profile, "profile", "", "Profile of a credential file (aws, default: default)")
+	o.Stdin = map[string]*bool{}
+	for _, f := range o.secretFields() {
+		o.Stdin[f.Flag] = cmd.Flags().Bool(f.Flag+"-stdin", false, fmt.Sprintf("Read a %s from the standard-in", f.Flag))
+	}
 
 	return cmd
 
@@ -0,0 +1,162 @@
+package create
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/itnpeople/cbctl/app"
+	"github.com/itnpeople/cbctl/utils"
+)
+
+// the default profile of an AWS credentials file
+const AWS_DEFAULT_PROFILE = "default"
+
+// fills empty options from a native credential file of the CSP
+//   - aws       : ~/.aws/credentials (ini, --profile)
+//   - gcp       : a service-account key (json)
+//   - azure     : a SDK auth file (json, az ad sp create-for-rbac --sdk-auth)
+//   - alibaba   : an access-key (csv)
+//   - tencent   : ~/.tccli/default.credential (json)
+//   - ibm       : an api-key (json)
+//   - openstack : an openrc (shell)
+//   - cloudit   : a credential (shell)
+func (o *CredentialOptions) LoadFile(path string, profile string) error {
+
+	if profile != "" && o.CSP != "aws" {
+		return fmt.Errorf("A profile is supported only for aws (csp=%s, profile=%s)", o.CSP, profile)
+	}
+	b, err := ioutil.ReadFile(app.ExpandPath(path))
+	if err != nil {
+		return err
+	}
+	b = bytes.TrimPrefix(b, []byte("\xef\xbb\xbf")) // UTF-8 BOM
+
+	values := map[string]string{}
+	switch o.CSP {
+	case "aws":
+		if values, err = parseIni(b, utils.NVL(profile, AWS_DEFAULT_PROFILE)); err == nil {
+			o.ClientID = utils.NVL(o.ClientID, values["aws_access_key_id"])
+			o.ClientSecret = utils.NVL(o.ClientSecret, values["aws_secret_access_key"])
+		}
+	case "gcp":
+		if err = json.Unmarshal(b, &values); err == nil {
+			o.ProjectID = utils.NVL(o.ProjectID, values["project_id"])
+			o.PrivateKey = utils.NVL(o.PrivateKey, values["private_key"])
+			o.ClientEmail = utils.NVL(o.ClientEmail, values["client_email"])
+		}
+	case "azure":
+		if err = json.Unmarshal(b, &values); err == nil {
+			o.SubscriptionId = utils.NVL(o.SubscriptionId, values["subscriptionId"])
+			o.TenantId = utils.NVL(o.TenantId, values["tenantId"])
+			o.ClientID = utils.NVL(o.ClientID, values["clientId"])
+			o.ClientSecret = utils.NVL(o.ClientSecret, values["clientSecret"])
+		}
+	case "alibaba":
+		if values, err = parseCsv(b); err == nil {
+			o.ClientID = utils.NVL(o.ClientID, values["accesskeyid"])
+			o.ClientSecret = utils.NVL(o.ClientSecret, values["accesskeysecret"])
+		}
+	case "tencent":
+		if err = json.Unmarshal(b, &values); err == nil {
+			o.ClientID = utils.NVL(o.ClientID, values["secretId"])
+			o.ClientSecret = utils.NVL(o.ClientSecret, values["secretKey"])
+		}
+	case "ibm":
+		if err = json.Unmarshal(b, &values); err == nil {
+			o.ApiKey = utils.NVL(o.ApiKey, values["apikey"])
+		}
+	case "openstack":
+		values = parseShell(b)
+		o.Endpoint = utils.NVL(o.Endpoint, values["OS_AUTH_URL"])
+		o.Username = utils.NVL(o.Username, values["OS_USERNAME"])
+		o.Password = utils.NVL(o.Password, values["OS_PASSWORD"])
+		o.DomainName = utils.NVL(o.DomainName, values["OS_USER_DOMAIN_NAME"])
+		o.ProjectID = utils.NVL(o.ProjectID, values["OS_PROJECT_ID"])
+	case "cloudit":
+		values = parseShell(b)
+		o.Endpoint = utils.NVL(o.Endpoint, values["CLOUDIT_ENDPOINT"])
+		o.AutoToken = utils.NVL(o.AutoToken, values["CLOUDIT_TOKEN"])
+		o.Username = utils.NVL(o.Username, values["CLOUDIT_USERNAME"])
+		o.Password = utils.NVL(o.Password, values["CLOUDIT_PASSWORD"])
+		o.TenantId = utils.NVL(o.TenantId, values["CLOUDIT_TENANT_ID"])
+	default:
+		return fmt.Errorf("Not supported CSP (csp=%s)", o.CSP)
+	}
+	if err != nil {
+		return fmt.Errorf("Invalid credential file (csp=%s, file=%s, cause=%v)", o.CSP, path, err)
+	}
+	return nil
+}
+
+// returns key-values of a section of a ini file (eg. [default] aws_access_key_id = ...)
+func parseIni(b []byte, section string) (map[string]string, error) {
+
+	values := map[string]string{}
+	found, current := false, ""
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			current = strings.TrimSpace(line[1 : len(line)-1])
+			found = found || (current == section)
+			continue
+		}
+		if kv := strings.SplitN(line, "=", 2); len(kv) == 2 && current == section {
+			values[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("Not found a profile (profile=%s)", section)
+	}
+	return values, nil
+}
+
+// returns values of the first record of a csv file keyed by lower-case headers without spaces (eg. "accesskeyid")
+func parseCsv(b []byte) (map[string]string, error) {
+
+	records, err := csv.NewReader(bytes.NewReader(b)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) < 2 {
+		return nil, fmt.Errorf("Not found a record")
+	}
+	values := map[string]string{}
+	for i, h := range records[0] {
+		if i < len(records[1]) {
+			values[strings.ToLower(strings.ReplaceAll(h, " ", ""))] = strings.TrimSpace(records[1][i])
+		}
+	}
+	return values, nil
+}
+
+// returns variables of a shell script (eg. export OS_USERNAME="...", variable references are ignored)
+func parseShell(b []byte) map[string]string {
+
+	values := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		line := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(scanner.Text()), "export "))
+		kv := strings.SplitN(line, "=", 2)
+		if len(kv) != 2 || strings.HasPrefix(line, "#") || strings.ContainsAny(kv[0], " \t") {
+			continue
+		}
+		value := strings.TrimSpace(kv[1])
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		if strings.HasPrefix(value, "$") {
+			continue
+		}
+		values[kv[0]] = value
+	}
+	return values
+}
@@ -0,0 +1,101 @@
+package create
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/itnpeople/cbctl/app"
+)
+
+// a secret field of a credential
+type secretField struct {
+	Name  string  // a key of the key-value list
+	Flag  string  // a flag name
+	Value *string // a field of options
+}
+
+// required secrets of CSPs
+var cspSecrets = map[string][]string{
+	"aws":       {"ClientSecret"},
+	"alibaba":   {"ClientSecret"},
+	"tencent":   {"ClientSecret"},
+	"azure":     {"ClientSecret"},
+	"gcp":       {"PrivateKey"},
+	"ibm":       {"ApiKey"},
+	"openstack": {"Password"},
+	"cloudit":   {"Password", "AuthToken"},
+}
+
+// returns all secret fields
+func (o *CredentialOptions) secretFields() []secretField {
+	return []secretField{
+		{"ClientSecret", "secret", &o.ClientSecret},
+		{"Password", "password", &o.Password},
+		{"PrivateKey", "private-key", &o.PrivateKey},
+		{"ApiKey", "api-key", &o.ApiKey},
+		{"AuthToken", "token", &o.AutoToken},
+	}
+}
+
+// reads secrets from the standard-in (--*-stdin) or prompts missing secrets without echo (a terminal only)
+// multiple secrets of the standard-in are read one per line in order of flags (secret, password, private-key, api-key, token)
+func (o *CredentialOptions) ReadSecrets() error {
+
+	// --secret-stdin, --password-stdin, ...
+	fields := []secretField{}
+	for _, f := range o.secretFields() {
+		if ok := o.Stdin[f.Flag]; ok != nil && *ok {
+			fields = append(fields, f)
+		}
+	}
+	if len(fields) > 0 {
+		if o.Filename == "-" {
+			return fmt.Errorf("The standard-in is already used by a file (-f -)")
+		}
+		value, err := app.ReadStdin()
+		if err != nil {
+			return err
+		}
+		if len(fields) == 1 {
+			*fields[0].Value = value
+		} else {
+			lines := strings.Split(strings.ReplaceAll(value, "\r\n", "\n"), "\n")
+			flags := []string{}
+			for _, f := range fields {
+				flags = append(flags, "--"+f.Flag+"-stdin")
+			}
+			if len(lines) != len(fields) {
+				return fmt.Errorf("Multiple secrets of the standard-in require one secret per line in order of flags (flags=%s, lines=%d)", strings.Join(flags, ","), len(lines))
+			}
+			for i, f := range fields {
+				if f.Name == "PrivateKey" {
+					lines[i] = strings.ReplaceAll(lines[i], `\n`, "\n") // a single line private-key
+				}
+				*f.Value = lines[i]
+			}
+		}
+	}
+
+	// prompts
+	if !app.IsTerminal() {
+		return nil
+	}
+	required := map[string]bool{}
+	for _, name := range cspSecrets[o.CSP] {
+		required[name] = true
+	}
+	for _, f := range o.secretFields() {
+		if !required[f.Name] || *f.Value != "" {
+			continue
+		}
+		value, err := app.ReadSecret(fmt.Sprintf("%s (%s): ", f.Name, o.CSP))
+		if err != nil {
+			return err
+		}
+		if f.Name == "PrivateKey" {
+			value = strings.ReplaceAll(value, `\n`, "\n") // a single line private-key
+		}
+		*f.Value = value
+	}
+	return nil
+}
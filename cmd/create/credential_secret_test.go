@@ -0,0 +1,62 @@
+package create
+
+import (
+	"os"
+	"testing"
+
+	"github.com/itnpeople/cbctl/app"
+)
+
+// runs ReadSecrets with the standard-in
+func readSecrets(t *testing.T, o *CredentialOptions, stdin string) error {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.WriteString(stdin)
+	w.Close()
+	defer func(f *os.File) { os.Stdin = f }(os.Stdin)
+	os.Stdin = r
+	return o.ReadSecrets()
+}
+
+func stdinFlags(flags ...string) map[string]*bool {
+	m := map[string]*bool{}
+	for _, f := range flags {
+		ok := true
+		m[f] = &ok
+	}
+	return m
+}
+
+func TestReadSecrets(t *testing.T) {
+	o := &CredentialOptions{Options: &app.Options{}, CSP: "aws", Stdin: stdinFlags("secret")}
+	if err := readSecrets(t, o, "s3cr3t\n"); err != nil || o.ClientSecret != "s3cr3t" {
+		t.Errorf("ReadSecrets() = %q, %v", o.ClientSecret, err)
+	}
+
+	// one per line in order of flags
+	o = &CredentialOptions{Options: &app.Options{}, CSP: "cloudit", Stdin: stdinFlags("token", "password")}
+	if err := readSecrets(t, o, "pw\r\ntok\n"); err != nil || o.Password != "pw" || o.AutoToken != "tok" {
+		t.Errorf("ReadSecrets() = %q, %q, %v", o.Password, o.AutoToken, err)
+	}
+	o = &CredentialOptions{Options: &app.Options{}, CSP: "cloudit", Stdin: stdinFlags("token", "password")}
+	if err := readSecrets(t, o, "pw\n"); err == nil {
+		t.Error("ReadSecrets() of missing lines expected an error")
+	}
+
+	// the standard-in of a file
+	o = &CredentialOptions{Options: &app.Options{Filename: "-"}, CSP: "aws", Stdin: stdinFlags("secret")}
+	if err := readSecrets(t, o, "s3cr3t\n"); err == nil {
+		t.Error("ReadSecrets() with -f - expected an error")
+	}
+}
+
+func TestMissingFlags(t *testing.T) {
+	if got := missingFlags("secret-id", "AKIA", "secret", "", "tenant", ""); got != "--secret,--tenant" {
+		t.Errorf("missingFlags() = %q", got)
+	}
+	if got := missingFlags("secret-id", "AKIA"); got != "" {
+		t.Errorf("missingFlags() = %q", got)
+	}
+}
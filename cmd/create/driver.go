@@ -3,10 +3,11 @@ package create
 import (
 	"fmt"
 
-	"github.com/go-resty/resty/v2"
 	"github.com/spf13/cobra"
 
 	"github.com/itnpeople/cbctl/app"
+	"github.com/itnpeople/cbctl/client"
+	"github.com/itnpeople/cbctl/schema"
 )
 
 // a struct to support command
@@ -32,18 +33,17 @@ func NewCommandDriver(options *app.Options) *cobra.Command {
 				if o.Filename == "" && o.CSP == "" {
 					return fmt.Errorf("CSP is required.")
 				}
-				if out, err := app.GetBody(o, `{
+				if out, err := schema.GetBody(o, "driver", `{
 					"DriverName"        : "{{ .CSP }}-driver-v1.0",
 					"ProviderName"      : "{{ .CSP | ToUpper }}",
 					"DriverLibFileName" : "{{ .CSP }}-driver-v1.0.so"
 				}`); err != nil {
 					return err
 				} else {
-					url := fmt.Sprintf("%s/driver", app.Config.GetCurrentContext().Urls.Spider)
-					if resp, err := resty.New().SetDisableWarn(true).R().SetHeader("content-type", "application/json").SetBody(out).Post(url); err != nil {
+					if res, err := client.NewSpider(app.Config.GetCurrentContext()).CreateDriver(out); err != nil {
+						return err
+					} else if err := o.WriteObject(res); err != nil {
 						return err
-					} else {
-						o.WriteBody(resp.Body())
 					}
 				}
 				return nil
@@ -0,0 +1,63 @@
+package create
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+)
+
+// network CNIs supported by MCKS
+var NETWORK_CNIS = []string{"canal", "kilo"}
+
+// defaults of kubernetes config
+const (
+	DEFAULT_NETWORK_CNI        = "canal"
+	DEFAULT_POD_CIDR           = "10.244.0.0/16"
+	DEFAULT_SERVICE_CIDR       = "10.96.0.0/12"
+	DEFAULT_SERVICE_DNS_DOMAIN = "cluster.local"
+)
+
+var (
+	versionRegexp = regexp.MustCompile(`^v?\d+\.\d+(\.\d+)?$`)
+	domainRegexp  = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+)
+
+// kubernetes config of a cluster
+type KubernetesConfig struct {
+	Version          string
+	NetworkCni       string
+	PodCidr          string
+	ServiceCidr      string
+	ServiceDnsDomain string
+}
+
+// validates a CNI, CIDRs (syntax and overlap of pod and service ranges), a DNS domain and a version
+func (k *KubernetesConfig) Validate() error {
+
+	cni := false
+	for _, c := range NETWORK_CNIS {
+		cni = cni || (c == k.NetworkCni)
+	}
+	if !cni {
+		return fmt.Errorf("Not supported network CNI (network-cni=%s, allowed=%s)", k.NetworkCni, strings.Join(NETWORK_CNIS, ","))
+	}
+	_, pod, err := net.ParseCIDR(k.PodCidr)
+	if err != nil {
+		return fmt.Errorf("Invalid pod CIDR (pod-cidr=%s)", k.PodCidr)
+	}
+	_, service, err := net.ParseCIDR(k.ServiceCidr)
+	if err != nil {
+		return fmt.Errorf("Invalid service CIDR (service-cidr=%s)", k.ServiceCidr)
+	}
+	if pod.Contains(service.IP) || service.Contains(pod.IP) {
+		return fmt.Errorf("Pod and service CIDRs overlap (pod-cidr=%s, service-cidr=%s)", k.PodCidr, k.ServiceCidr)
+	}
+	if !domainRegexp.MatchString(k.ServiceDnsDomain) {
+		return fmt.Errorf("Invalid service DNS domain (service-dns-domain=%s)", k.ServiceDnsDomain)
+	}
+	if k.Version != "" && !versionRegexp.MatchString(k.Version) {
+		return fmt.Errorf("Invalid kubernetes version (kubernetes-version=%s, eg. 1.23.13)", k.Version)
+	}
+	return nil
+}
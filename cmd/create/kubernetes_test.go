@@ -0,0 +1,61 @@
+package create
+
+import (
+	"testing"
+)
+
+func TestKubernetesConfigValidate(t *testing.T) {
+	valid := func() KubernetesConfig {
+		return KubernetesConfig{NetworkCni: DEFAULT_NETWORK_CNI, PodCidr: DEFAULT_POD_CIDR, ServiceCidr: DEFAULT_SERVICE_CIDR, ServiceDnsDomain: DEFAULT_SERVICE_DNS_DOMAIN}
+	}
+	tests := []struct {
+		name    string
+		modify  func(k *KubernetesConfig)
+		wantErr bool
+	}{
+		{"defaults", func(k *KubernetesConfig) {}, false},
+
+		// network CNI
+		{"kilo", func(k *KubernetesConfig) { k.NetworkCni = "kilo" }, false},
+		{"unsupported cni", func(k *KubernetesConfig) { k.NetworkCni = "calico" }, true},
+		{"empty cni", func(k *KubernetesConfig) { k.NetworkCni = "" }, true},
+		{"upper-case cni", func(k *KubernetesConfig) { k.NetworkCni = "Canal" }, true},
+
+		// CIDRs
+		{"pod cidr", func(k *KubernetesConfig) { k.PodCidr = "172.20.0.0/16" }, false},
+		{"pod cidr without a mask", func(k *KubernetesConfig) { k.PodCidr = "10.244.0.0" }, true},
+		{"invalid pod cidr", func(k *KubernetesConfig) { k.PodCidr = "10.244.0.256/16" }, true},
+		{"service cidr", func(k *KubernetesConfig) { k.ServiceCidr = "172.21.0.0/16" }, false},
+		{"invalid service cidr", func(k *KubernetesConfig) { k.ServiceCidr = "10.96.0.0/33" }, true},
+
+		// overlap
+		{"same cidrs", func(k *KubernetesConfig) { k.ServiceCidr = k.PodCidr }, true},
+		{"service cidr in a pod cidr", func(k *KubernetesConfig) { k.PodCidr, k.ServiceCidr = "10.0.0.0/8", "10.96.0.0/12" }, true},
+		{"pod cidr in a service cidr", func(k *KubernetesConfig) { k.PodCidr, k.ServiceCidr = "10.100.0.0/16", "10.96.0.0/12" }, true},
+		{"adjacent cidrs", func(k *KubernetesConfig) { k.PodCidr, k.ServiceCidr = "10.244.0.0/16", "10.245.0.0/16" }, false},
+
+		// service DNS domain
+		{"dns domain", func(k *KubernetesConfig) { k.ServiceDnsDomain = "my-cluster.local" }, false},
+		{"single label dns domain", func(k *KubernetesConfig) { k.ServiceDnsDomain = "local" }, false},
+		{"empty dns domain", func(k *KubernetesConfig) { k.ServiceDnsDomain = "" }, true},
+		{"upper-case dns domain", func(k *KubernetesConfig) { k.ServiceDnsDomain = "Cluster.local" }, true},
+		{"dns domain of a leading hyphen", func(k *KubernetesConfig) { k.ServiceDnsDomain = "-cluster.local" }, true},
+		{"dns domain of a trailing dot", func(k *KubernetesConfig) { k.ServiceDnsDomain = "cluster.local." }, true},
+		{"dns domain of an empty label", func(k *KubernetesConfig) { k.ServiceDnsDomain = "cluster..local" }, true},
+
+		// version
+		{"version", func(k *KubernetesConfig) { k.Version = "1.23.13" }, false},
+		{"version with v", func(k *KubernetesConfig) { k.Version = "v1.23.13" }, false},
+		{"minor version", func(k *KubernetesConfig) { k.Version = "1.23" }, false},
+		{"major version", func(k *KubernetesConfig) { k.Version = "1" }, true},
+		{"pre-release version", func(k *KubernetesConfig) { k.Version = "1.23.13-rc.0" }, true},
+		{"not a version", func(k *KubernetesConfig) { k.Version = "latest" }, true},
+	}
+	for _, tt := range tests {
+		k := valid()
+		tt.modify(&k)
+		if err := k.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: Validate() error = %v, want %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
@@ -3,10 +3,11 @@ package create
 import (
 	"fmt"
 
-	"github.com/go-resty/resty/v2"
 	"github.com/spf13/cobra"
 
 	"github.com/itnpeople/cbctl/app"
+	"github.com/itnpeople/cbctl/client"
+	"github.com/itnpeople/cbctl/schema"
 )
 
 // a struct to support command
@@ -31,17 +32,16 @@ func NewCommandNamespace(options *app.Options) *cobra.Command {
 				if o.Filename == "" && o.Name == "" {
 					return fmt.Errorf("Name is required.")
 				}
-				if out, err := app.GetBody(o, `{
+				if out, err := schema.GetBody(o, "namespace", `{
 					"name"        : "{{ .Name }}",
 					"description" : "{{ .Description }}"
 				}`); err != nil {
 					return err
 				} else {
-					url := fmt.Sprintf("%s/ns", app.Config.GetCurrentContext().Urls.Tumblebug)
-					if resp, err := resty.New().SetDisableWarn(true).R().SetBasicAuth("default", "default").SetHeader("content-type", "application/json").SetBody(out).Post(url); err != nil {
+					if res, err := client.NewTumblebug(app.Config.GetCurrentContext()).CreateNamespace(out); err != nil {
+						return err
+					} else if err := o.WriteObject(res); err != nil {
 						return err
-					} else {
-						o.WriteBody(resp.Body())
 					}
 				}
 				return nil
@@ -0,0 +1,44 @@
+package create
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/itnpeople/cbctl/app"
+)
+
+// parses node-pool flags (eg. "connection=config-aws-tokyo,count=2,spec=t2.medium", count is 1 if omitted)
+func ParseNodePools(flag string, values []string) ([]app.NodePool, error) {
+
+	pools := []app.NodePool{}
+	for _, value := range values {
+		pool := app.NodePool{Count: 1}
+		for _, kv := range strings.Split(value, ",") {
+			e := strings.SplitN(kv, "=", 2)
+			if len(e) != 2 {
+				return nil, fmt.Errorf("Invalid --%s flag, expected KEY=VALUE (value=%s)", flag, value)
+			}
+			k, v := strings.TrimSpace(e[0]), strings.TrimSpace(e[1])
+			switch k {
+			case "connection":
+				pool.Connection = v
+			case "spec":
+				pool.Spec = v
+			case "count":
+				count, err := strconv.Atoi(v)
+				if err != nil || count < 1 {
+					return nil, fmt.Errorf("Invalid --%s flag, count must be a positive integer (value=%s)", flag, value)
+				}
+				pool.Count = count
+			default:
+				return nil, fmt.Errorf("Invalid --%s flag, unknown key '%s' (allowed=connection,count,spec, value=%s)", flag, k, value)
+			}
+		}
+		if pool.Connection == "" || pool.Spec == "" {
+			return nil, fmt.Errorf("Invalid --%s flag, connection and spec are required (value=%s)", flag, value)
+		}
+		pools = append(pools, pool)
+	}
+	return pools, nil
+}
@@ -0,0 +1,24 @@
+package create
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/itnpeople/cbctl/app"
+)
+
+func TestParseNodePools(t *testing.T) {
+	pools, err := ParseNodePools("worker", []string{"connection=config-aws,spec=t2.medium", " connection = config-gcp , count=3, spec=e2-medium"})
+	if err != nil {
+		t.Fatalf("ParseNodePools() : %v", err)
+	}
+	want := []app.NodePool{{Connection: "config-aws", Count: 1, Spec: "t2.medium"}, {Connection: "config-gcp", Count: 3, Spec: "e2-medium"}}
+	if !reflect.DeepEqual(pools, want) {
+		t.Errorf("ParseNodePools() = %v, want %v", pools, want)
+	}
+	for _, value := range []string{"connection=config-aws", "connection=config-aws,spec=t2.medium,count=0", "connection=config-aws,spec=t2.medium,count=x", "connection=config-aws,spec=t2.medium,zone=a", "config-aws"} {
+		if _, err := ParseNodePools("worker", []string{value}); err == nil {
+			t.Errorf("ParseNodePools(%q) expected an error", value)
+		}
+	}
+}
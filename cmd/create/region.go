@@ -3,10 +3,11 @@ package create
 import (
 	"fmt"
 
-	"github.com/go-resty/resty/v2"
 	"github.com/spf13/cobra"
 
 	"github.com/itnpeople/cbctl/app"
+	"github.com/itnpeople/cbctl/client"
+	"github.com/itnpeople/cbctl/schema"
 )
 
 // a struct to support command
@@ -38,10 +39,10 @@ func NewCommandRegion(options *app.Options) *cobra.Command {
 						return fmt.Errorf("CSP is required.")
 					}
 					if o.CSP == "azure" && (o.Location == "" || o.ResourceGroup == "") {
-						return fmt.Errorf("Invalid location/resource-group flag (csp=%s, key=%s, secret=%s)", o.CSP, o.Location, o.ResourceGroup)
+						return fmt.Errorf("Invalid location/resource-group flag (csp=%s, location=%s, resource-group=%s)", o.CSP, o.Location, o.ResourceGroup)
 					}
 				}
-				if out, err := app.GetBody(o, `{
+				if out, err := schema.GetBody(o, "region", `{
 					"RegionName"       : "{{ .Name }}",
 					"ProviderName"     : "{{ .CSP | ToUpper }}", 
 					"KeyValueInfoList" : [
@@ -53,11 +54,10 @@ func NewCommandRegion(options *app.Options) *cobra.Command {
 				}`); err != nil {
 					return err
 				} else {
-					url := fmt.Sprintf("%s/region", app.Config.GetCurrentContext().Urls.Spider)
-					if resp, err := resty.New().SetDisableWarn(true).R().SetHeader("content-type", "application/json").SetBody(out).Post(url); err != nil {
+					if res, err := client.NewSpider(app.Config.GetCurrentContext()).CreateRegion(out); err != nil {
+						return err
+					} else if err := o.WriteObject(res); err != nil {
 						return err
-					} else {
-						o.WriteBody(resp.Body())
 					}
 				}
 				return nil
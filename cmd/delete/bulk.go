@@ -0,0 +1,250 @@
+package delete
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	"github.com/itnpeople/cbctl/app"
+	"github.com/itnpeople/cbctl/utils"
+)
+
+const DEFAULT_CONCURRENCY = 5
+
+// a struct to support bulk deletion (a name pattern, "--all" or "--selector")
+type BulkOptions struct {
+	*app.Options
+	All         bool
+	Selector    string
+	Concurrency int
+}
+
+// a result of a deletion
+type BulkResult struct {
+	Kind    string `json:"kind"`
+	Name    string `json:"name"`
+	Result  string `json:"result"`
+	Message string `json:"message,omitempty"`
+}
+
+// results of a bulk deletion
+type BulkResultList struct {
+	Items []BulkResult `json:"items"`
+}
+
+// a requirement of a selector (eg. "status=Failed", "label!=ci-*")
+type requirement struct {
+	Field  string
+	Equals bool
+	Value  string
+}
+
+// results
+const (
+	RESULT_DELETED = "deleted"
+	RESULT_FAILED  = "failed"
+	RESULT_DRY_RUN = "dry-run"
+)
+
+// adds bulk flags (--all, --selector, --concurrency)
+func (b *BulkOptions) AddFlags(c *cobra.Command) {
+	c.PersistentFlags().BoolVar(&b.All, "all", false, "Delete all objects")
+	c.PersistentFlags().StringVarP(&b.Selector, "selector", "l", "", "Delete objects matched by fields (eg. status=Failed,label=ci-*)")
+	c.PersistentFlags().IntVar(&b.Concurrency, "concurrency", DEFAULT_CONCURRENCY, "Max. number of concurrent deletions")
+}
+
+// returns a plural form of a kind (eg. "cluster" -> "clusters", "mcis" -> "mcis")
+func plural(n int, kind string) string {
+	if n == 1 || strings.HasSuffix(kind, "s") {
+		return kind
+	}
+	return kind + "s"
+}
+
+// whether a name is a glob pattern (eg. "ci-*")
+func isPattern(name string) bool {
+	return strings.ContainsAny(name, "*?[")
+}
+
+// whether to delete multiple objects
+func (b *BulkOptions) IsBulk() bool {
+	return b.All || b.Selector != "" || isPattern(b.Name)
+}
+
+// validates bulk flags
+func (b *BulkOptions) Validate() error {
+	if b.All && b.Name != "" {
+		return fmt.Errorf("A name is not allowed with --all (name=%s)", b.Name)
+	}
+	if b.Concurrency < 1 {
+		return fmt.Errorf("Invalid concurrency (concurrency=%d)", b.Concurrency)
+	}
+	if _, err := path.Match(b.Name, ""); err != nil {
+		return fmt.Errorf("Invalid name pattern (name=%s)", b.Name)
+	}
+	_, err := parseSelector(b.Selector)
+	return err
+}
+
+// parses a selector (comma separated "field=value" or "field!=value", values are glob patterns)
+func parseSelector(selector string) ([]requirement, error) {
+	reqs := []requirement{}
+	for _, s := range strings.Split(selector, ",") {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+		req := requirement{Equals: true}
+		if i := strings.Index(s, "!="); i > 0 {
+			req.Field, req.Value, req.Equals = s[:i], s[i+2:], false
+		} else if i := strings.Index(s, "="); i > 0 {
+			req.Field, req.Value = s[:i], strings.TrimPrefix(s[i+1:], "=")
+		} else {
+			return nil, fmt.Errorf("Invalid selector (selector=%s, expected=field=value|field!=value)", s)
+		}
+		if _, err := path.Match(req.Value, ""); err != nil {
+			return nil, fmt.Errorf("Invalid selector (selector=%s, cause=%v)", s, err)
+		}
+		reqs = append(reqs, req)
+	}
+	return reqs, nil
+}
+
+// whether top-level fields of an object satisfy requirements (field names are case-insensitive)
+func matches(obj interface{}, reqs []requirement) bool {
+	if len(reqs) == 0 {
+		return true
+	}
+	fields := map[string]interface{}{}
+	if b, err := json.Marshal(obj); err != nil || json.Unmarshal(b, &fields) != nil {
+		return false
+	}
+	for _, req := range reqs {
+		value := ""
+		for k, v := range fields {
+			if strings.EqualFold(k, req.Field) && v != nil {
+				value = fmt.Sprint(v)
+			}
+		}
+		if ok, _ := path.Match(req.Value, value); ok != req.Equals {
+			return false
+		}
+	}
+	return true
+}
+
+// deletes objects of a list matched by a name pattern, "--all" or "--selector" (lists matches, confirms, deletes concurrently and prints results)
+// a namespace is empty for objects not in a namespace (drivers, credentials, regions, connections, namespaces)
+func (b *BulkOptions) Run(kind string, namespace string, list func() (app.ListObject, error), del func(name string) error) error {
+
+	objs, err := list()
+	if err != nil {
+		return err
+	}
+	reqs, _ := parseSelector(b.Selector)
+	pattern := utils.NVL(b.Name, "*")
+	names := []string{}
+	for _, item := range objs.GetItems() {
+		if n, ok := item.(app.NamedObject); ok {
+			if ok, _ := path.Match(pattern, n.GetName()); ok && matches(item, reqs) {
+				names = append(names, n.GetName())
+			}
+		}
+	}
+	if len(names) == 0 {
+		fmt.Fprintf(os.Stderr, "No %s matched (name=%s, selector=%s)\n", plural(0, kind), pattern, app.None(b.Selector))
+		return nil
+	}
+
+	// confirm
+	objects := []string{}
+	for _, name := range names {
+		objects = append(objects, kind+"/"+name)
+	}
+	if ok, err := b.Confirm(app.Confirmation{Action: "deleted", Namespace: namespace, Objects: objects}); err != nil {
+		return err
+	} else if !ok {
+		fmt.Fprintln(os.Stderr, "Cancelled")
+		return nil
+	}
+
+	// delete (requests of dry-run mode are printed in order)
+	concurrency := b.Concurrency
+	if b.IsDryRun() {
+		concurrency = 1
+	}
+	results := &BulkResultList{Items: make([]BulkResult, len(names))}
+	sem := make(chan struct{}, concurrency)
+	wg := sync.WaitGroup{}
+	for i, name := range names {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, name string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			r := BulkResult{Kind: kind, Name: name, Result: RESULT_DELETED}
+			if err := del(name); err != nil {
+				r.Result, r.Message = RESULT_FAILED, err.Error()
+			} else if b.IsDryRun() {
+				r.Result = RESULT_DRY_RUN
+			}
+			results.Items[i] = r
+		}(i, name)
+	}
+	wg.Wait()
+
+	if !b.IsDryRun() {
+		if err := b.WriteObject(results); err != nil {
+			return err
+		}
+	}
+	if failed := results.Failed(); failed > 0 {
+		return &app.ExitError{Code: app.EXIT_ERROR, Message: fmt.Sprintf("Failed to delete %d of %d %s", failed, len(names), plural(len(names), kind))}
+	}
+	return nil
+}
+
+// returns the number of failed deletions
+func (obj *BulkResultList) Failed() int {
+	n := 0
+	for _, r := range obj.Items {
+		if r.Result == RESULT_FAILED {
+			n++
+		}
+	}
+	return n
+}
+
+func (obj *BulkResult) GetKind() string {
+	return obj.Kind
+}
+
+func (obj *BulkResult) GetName() string {
+	return obj.Name
+}
+
+func (obj *BulkResultList) GetItems() []interface{} {
+	items := []interface{}{}
+	for i := range obj.Items {
+		items = append(items, &obj.Items[i])
+	}
+	return items
+}
+
+func (obj *BulkResultList) Columns(wide bool) []string {
+	return []string{"KIND", "NAME", "RESULT", "MESSAGE"}
+}
+
+func (obj *BulkResultList) Rows(wide bool) [][]string {
+	rows := [][]string{}
+	for _, r := range obj.Items {
+		rows = append(rows, []string{r.Kind, r.Name, r.Result, app.None(r.Message)})
+	}
+	return rows
+}
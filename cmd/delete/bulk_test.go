@@ -0,0 +1,102 @@
+package delete
+
+import (
+	"testing"
+
+	"github.com/itnpeople/cbctl/app"
+)
+
+type testObject struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Label  string `json:"label"`
+	Count  int    `json:"count"`
+}
+
+func TestParseSelector(t *testing.T) {
+	tests := []struct {
+		selector string
+		want     []requirement
+		wantErr  bool
+	}{
+		{"", []requirement{}, false},
+		{"status=Failed", []requirement{{"status", true, "Failed"}}, false},
+		{"status==Failed", []requirement{{"status", true, "Failed"}}, false},
+		{"label!=ci-*, status=Failed", []requirement{{"label", false, "ci-*"}, {"status", true, "Failed"}}, false},
+		{"status", nil, true},
+		{"=Failed", nil, true},
+		{"label=[", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := parseSelector(tt.selector)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseSelector(%q) error = %v, want %v", tt.selector, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !equals(got, tt.want) {
+			t.Errorf("parseSelector(%q) = %v, want %v", tt.selector, got, tt.want)
+		}
+	}
+}
+
+func equals(a, b []requirement) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestMatches(t *testing.T) {
+	obj := &testObject{Name: "ci-1", Status: "Failed", Label: "ci-test", Count: 3}
+	tests := []struct {
+		selector string
+		want     bool
+	}{
+		{"", true},
+		{"status=Failed", true},
+		{"STATUS=Failed", true},
+		{"status=Running", false},
+		{"label=ci-*", true},
+		{"label!=ci-*", false},
+		{"status=Failed,label=dev-*", false},
+		{"count=3", true},
+		{"unknown=", true},
+		{"unknown=x", false},
+	}
+	for _, tt := range tests {
+		reqs, err := parseSelector(tt.selector)
+		if err != nil {
+			t.Fatalf("parseSelector(%q) : %v", tt.selector, err)
+		}
+		if got := matches(obj, reqs); got != tt.want {
+			t.Errorf("matches(%q) = %v, want %v", tt.selector, got, tt.want)
+		}
+	}
+}
+
+func TestBulkValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		bulk    BulkOptions
+		wantErr bool
+	}{
+		{"a pattern", BulkOptions{Options: &app.Options{Name: "ci-*"}, Concurrency: 1}, false},
+		{"all with a name", BulkOptions{Options: &app.Options{Name: "c1"}, All: true, Concurrency: 1}, true},
+		{"invalid concurrency", BulkOptions{Options: &app.Options{}, All: true}, true},
+		{"invalid pattern", BulkOptions{Options: &app.Options{Name: "ci-["}, Concurrency: 1}, true},
+		{"invalid selector", BulkOptions{Options: &app.Options{}, Selector: "status", Concurrency: 1}, true},
+	}
+	for _, tt := range tests {
+		if err := tt.bulk.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: Validate() error = %v, want %v", tt.name, err, tt.wantErr)
+		}
+	}
+	if !(&BulkOptions{Options: &app.Options{Name: "ci-?"}}).IsBulk() || (&BulkOptions{Options: &app.Options{Name: "c1"}}).IsBulk() {
+		t.Error("IsBulk() of a name pattern")
+	}
+}
@@ -2,11 +2,12 @@ package delete
 
 import (
 	"fmt"
+	"os"
 
-	"github.com/go-resty/resty/v2"
 	"github.com/spf13/cobra"
 
 	"github.com/itnpeople/cbctl/app"
+	"github.com/itnpeople/cbctl/client"
 	"github.com/itnpeople/cbctl/utils"
 )
 
@@ -14,42 +15,102 @@ import (
 func NewCommandDelete(o *app.Options) *cobra.Command {
 
 	o.Namespace = utils.NVL(o.Namespace, app.Config.GetCurrentContext().Namespace)
-	fnValidate := func() error {
+	b := &BulkOptions{Options: o}
+	fnNamespace := func() error {
 		if o.Namespace == "" {
 			return fmt.Errorf("Namespace is required.")
 		}
+		return nil
+	}
+	fnValidate := func() error {
+		if err := fnNamespace(); err != nil {
+			return err
+		}
 		if o.Name == "" {
 			return fmt.Errorf("Name is required.")
 		}
 		return nil
 	}
 
+	// write a object
+	fnWrite := func(obj interface{}, err error) error {
+		if err != nil {
+			return err
+		}
+		return o.WriteObject(obj)
+	}
+
+	// confirms a deletion of objects ("kind/name", returns false if cancelled)
+	fnConfirm := func(namespace string, objects ...string) (bool, error) {
+		ok, err := o.Confirm(app.Confirmation{Action: "deleted", Namespace: namespace, Objects: objects})
+		if err == nil && !ok {
+			fmt.Fprintln(os.Stderr, "Cancelled")
+		}
+		return ok, err
+	}
+
+	// returns "kind/name" of all objects of a list
+	fnObjects := func(kind string, list func() (app.ListObject, error)) ([]string, error) {
+		objs, err := list()
+		if err != nil {
+			return nil, err
+		}
+		objects := []string{}
+		for _, item := range objs.GetItems() {
+			if n, ok := item.(app.NamedObject); ok {
+				objects = append(objects, kind+"/"+n.GetName())
+			}
+		}
+		return objects, nil
+	}
+
+	// deletes objects by a name pattern, "--all" or "--selector" (returns false if not a bulk deletion)
+	fnBulk := func(c *cobra.Command, kind string, namespace string, list func() (app.ListObject, error), del func(name string) error) bool {
+		if !b.IsBulk() {
+			return false
+		}
+		app.ValidateError(c, b.Validate())
+		app.ValidateError(c, b.Run(kind, namespace, list, del))
+		return true
+	}
+
 	// root
 	cmds := &cobra.Command{
 		Use:                   "delete",
-		Short:                 "Delete a object",
+		Short:                 "Delete objects",
 		DisableFlagsInUseLine: false,
 		Run: func(c *cobra.Command, args []string) {
 			c.Help()
 		},
 	}
 
+	o.AddDryRunFlag(cmds)
+	o.AddYesFlag(cmds)
+	b.AddFlags(cmds)
+
 	// cluster
 	cmds.AddCommand(&cobra.Command{
-		Use:                   "cluster (NAME | --name NAME) [options]",
+		Use:                   "cluster (NAME | PATTERN | --all | --selector SELECTOR) [options]",
 		Short:                 "Delete a cluster",
 		Args:                  app.BindCommandArgs(&o.Name),
 		DisableFlagsInUseLine: false,
 		Run: func(c *cobra.Command, args []string) {
+			mcks := client.NewMCKS(app.Config.GetCurrentContext())
+			app.ValidateError(c, fnNamespace())
+			if fnBulk(c, "cluster", o.Namespace, func() (app.ListObject, error) {
+				return mcks.ListClusters(o.Namespace)
+			}, func(name string) error {
+				_, err := mcks.DeleteCluster(o.Namespace, name)
+				return err
+			}) {
+				return
+			}
 			app.ValidateError(c, fnValidate())
 			app.ValidateError(c, func() error {
-				url := fmt.Sprintf("%s/ns/%s/clusters/%s", app.Config.GetCurrentContext().Urls.MCKS, o.Namespace, o.Name)
-				if resp, err := resty.New().SetDisableWarn(true).R().Delete(url); err != nil {
+				if ok, err := fnConfirm(o.Namespace, "cluster/"+o.Name); !ok {
 					return err
-				} else {
-					o.WriteBody(resp.Body())
 				}
-				return nil
+				return fnWrite(mcks.DeleteCluster(o.Namespace, o.Name))
 			}())
 		},
 	})
@@ -57,25 +118,31 @@ func NewCommandDelete(o *app.Options) *cobra.Command {
 	// node
 	var clusterName string
 	cmdNode := &cobra.Command{
-		Use:                   "node (NAME | --name NAME) --cluster CLUSTER_NAME [options]",
+		Use:                   "node (NAME | PATTERN | --all | --selector SELECTOR) --cluster CLUSTER_NAME [options]",
 		Short:                 "Get nodes",
 		Args:                  app.BindCommandArgs(&o.Name),
 		DisableFlagsInUseLine: true,
 		Run: func(c *cobra.Command, args []string) {
+			mcks := client.NewMCKS(app.Config.GetCurrentContext())
+			app.ValidateError(c, fnNamespace())
+			if clusterName == "" {
+				app.ValidateError(c, fmt.Errorf("Cluster name is required."))
+			}
+			if fnBulk(c, "node", o.Namespace, func() (app.ListObject, error) {
+				return mcks.ListNodes(o.Namespace, clusterName)
+			}, func(name string) error {
+				_, err := mcks.DeleteNode(o.Namespace, clusterName, name)
+				return err
+			}) {
+				return
+			}
 			app.ValidateError(c, fnValidate())
 			app.ValidateError(c, func() error {
-				if clusterName == "" {
-					return fmt.Errorf("Cluster name is required.")
-				}
-				url := fmt.Sprintf("%s/ns/%s/clusters/%s/nodes/%s", app.Config.GetCurrentContext().Urls.MCKS, o.Namespace, clusterName, o.Name)
-				if resp, err := resty.New().SetDisableWarn(true).R().Delete(url); err != nil {
+				if ok, err := fnConfirm(o.Namespace, "node/"+o.Name); !ok {
 					return err
-				} else {
-					o.WriteBody(resp.Body())
 				}
-				return nil
+				return fnWrite(mcks.DeleteNode(o.Namespace, clusterName, o.Name))
 			}())
-
 		},
 	}
 	cmdNode.Flags().StringVar(&clusterName, "cluster", "", "Name of cluster")
@@ -84,26 +151,31 @@ func NewCommandDelete(o *app.Options) *cobra.Command {
 	// driver
 	var csp string
 	cmdDrv := &cobra.Command{
-		Use:                   "driver (NAME | --name NAME) [options]",
+		Use:                   "driver (NAME | PATTERN | --all | --selector SELECTOR) [options]",
 		Short:                 "Delete a cloud driver",
 		Args:                  app.BindCommandArgs(&o.Name),
 		DisableFlagsInUseLine: true,
 		Run: func(c *cobra.Command, args []string) {
+			spider := client.NewSpider(app.Config.GetCurrentContext())
+			if fnBulk(c, "driver", "", func() (app.ListObject, error) {
+				return spider.ListDrivers()
+			}, func(name string) error {
+				_, err := spider.DeleteDriver(name)
+				return err
+			}) {
+				return
+			}
 			app.ValidateError(c, func() error {
-				url := fmt.Sprintf("%s/driver", app.Config.GetCurrentContext().Urls.Spider)
-				if o.Name != "" {
-					url += "/" + o.Name
-				} else if csp != "" {
-					url = fmt.Sprintf("%s/%s-driver-v1.0", url, csp)
-				} else {
+				name := o.Name
+				if name == "" && csp != "" {
+					name = client.DriverName(csp)
+				} else if name == "" {
 					return fmt.Errorf("Name is required.")
 				}
-				if resp, err := resty.New().SetDisableWarn(true).R().Delete(url); err != nil {
+				if ok, err := fnConfirm("", "driver/"+name); !ok {
 					return err
-				} else {
-					o.WriteBody(resp.Body())
 				}
-				return nil
+				return fnWrite(spider.DeleteDriver(name))
 			}())
 		},
 	}
@@ -112,239 +184,316 @@ func NewCommandDelete(o *app.Options) *cobra.Command {
 
 	// region
 	cmds.AddCommand(&cobra.Command{
-		Use:                   "region (NAME | --name NAME) [options]",
+		Use:                   "region (NAME | PATTERN | --all | --selector SELECTOR) [options]",
 		Short:                 "Delete a cloud region",
 		Args:                  app.BindCommandArgs(&o.Name),
 		DisableFlagsInUseLine: true,
 		Run: func(c *cobra.Command, args []string) {
+			spider := client.NewSpider(app.Config.GetCurrentContext())
+			if fnBulk(c, "region", "", func() (app.ListObject, error) {
+				return spider.ListRegions()
+			}, func(name string) error {
+				_, err := spider.DeleteRegion(name)
+				return err
+			}) {
+				return
+			}
 			app.ValidateError(c, func() error {
 				if o.Name == "" {
 					return fmt.Errorf("Name is required.")
 				}
-				if resp, err := resty.New().SetDisableWarn(true).R().Delete(fmt.Sprintf("%s/region/%s", app.Config.GetCurrentContext().Urls.Spider, o.Name)); err != nil {
+				if ok, err := fnConfirm("", "region/"+o.Name); !ok {
 					return err
-				} else {
-					o.WriteBody(resp.Body())
 				}
-				return nil
+				return fnWrite(spider.DeleteRegion(o.Name))
 			}())
 		},
 	})
 
 	// credential
 	cmds.AddCommand(&cobra.Command{
-		Use:                   "credential (NAME | --name NAME) [options]",
+		Use:                   "credential (NAME | PATTERN | --all | --selector SELECTOR) [options]",
 		Short:                 "Delete a cloud credential",
 		Args:                  app.BindCommandArgs(&o.Name),
 		DisableFlagsInUseLine: true,
 		Run: func(c *cobra.Command, args []string) {
+			spider := client.NewSpider(app.Config.GetCurrentContext())
+			if fnBulk(c, "credential", "", func() (app.ListObject, error) {
+				return spider.ListCredentials()
+			}, func(name string) error {
+				_, err := spider.DeleteCredential(name)
+				return err
+			}) {
+				return
+			}
 			app.ValidateError(c, func() error {
 				if o.Name == "" {
 					return fmt.Errorf("Name is required.")
 				}
-				if resp, err := resty.New().SetDisableWarn(true).R().Delete(fmt.Sprintf("%s/credential/%s", app.Config.GetCurrentContext().Urls.Spider, o.Name)); err != nil {
+				if ok, err := fnConfirm("", "credential/"+o.Name); !ok {
 					return err
-				} else {
-					o.WriteBody(resp.Body())
 				}
-				return nil
+				return fnWrite(spider.DeleteCredential(o.Name))
 			}())
 		},
 	})
 
 	// connection
 	cmds.AddCommand(&cobra.Command{
-		Use:                   "connection (NAME | --name NAME) [options]",
+		Use:                   "connection (NAME | PATTERN | --all | --selector SELECTOR) [options]",
 		Short:                 "Delete a cloud connection info.",
 		Args:                  app.BindCommandArgs(&o.Name),
 		DisableFlagsInUseLine: true,
 		Run: func(c *cobra.Command, args []string) {
+			spider := client.NewSpider(app.Config.GetCurrentContext())
+			if fnBulk(c, "connection", "", func() (app.ListObject, error) {
+				return spider.ListConnections()
+			}, func(name string) error {
+				_, err := spider.DeleteConnection(name)
+				return err
+			}) {
+				return
+			}
 			app.ValidateError(c, func() error {
 				if o.Name == "" {
 					return fmt.Errorf("Name is required.")
 				}
-				if resp, err := resty.New().SetDisableWarn(true).R().Delete(fmt.Sprintf("%s/connectionconfig/%s", app.Config.GetCurrentContext().Urls.Spider, o.Name)); err != nil {
+				if ok, err := fnConfirm("", "connection/"+o.Name); !ok {
 					return err
-				} else {
-					o.WriteBody(resp.Body())
 				}
-				return nil
+				return fnWrite(spider.DeleteConnection(o.Name))
 			}())
 		},
 	})
 
 	// namespace
 	cmds.AddCommand(&cobra.Command{
-		Use:                   "namespace (NAME | --name NAME) [options]",
+		Use:                   "namespace (NAME | PATTERN | --all | --selector SELECTOR) [options]",
 		Short:                 "Delete a namespace.",
 		Args:                  app.BindCommandArgs(&o.Name),
 		DisableFlagsInUseLine: true,
 		Run: func(c *cobra.Command, args []string) {
+			tumblebug := client.NewTumblebug(app.Config.GetCurrentContext())
+			if fnBulk(c, "namespace", "", func() (app.ListObject, error) {
+				return tumblebug.ListNamespaces()
+			}, func(name string) error {
+				_, err := tumblebug.DeleteNamespace(name)
+				return err
+			}) {
+				return
+			}
 			app.ValidateError(c, func() error {
 				if o.Name == "" {
 					return fmt.Errorf("Name is required.")
 				}
-				http := resty.New().SetDisableWarn(true).R().SetBasicAuth("default", "default")
-				if resp, err := http.Delete(fmt.Sprintf("%s/ns/%s", app.Config.GetCurrentContext().Urls.Tumblebug, o.Name)); err != nil {
+				if ok, err := fnConfirm(o.Name, "namespace/"+o.Name); !ok {
 					return err
-				} else {
-					o.WriteBody(resp.Body())
 				}
-				return nil
+				return fnWrite(tumblebug.DeleteNamespace(o.Name))
 			}())
 		},
 	})
 
 	// vpc
 	cmds.AddCommand(&cobra.Command{
-		Use:   "vpc (NAME | --name NAME) [options]",
+		Use:   "vpc (NAME | PATTERN | --all | --selector SELECTOR) [options]",
 		Short: "Delete VPCs.",
 		Args:  app.BindCommandArgs(&o.Name),
 		Run: func(c *cobra.Command, args []string) {
+			tumblebug := client.NewTumblebug(app.Config.GetCurrentContext())
+			app.ValidateError(c, fnNamespace())
+			if fnBulk(c, "vpc", o.Namespace, func() (app.ListObject, error) {
+				return tumblebug.ListVNets(o.Namespace)
+			}, func(name string) error {
+				_, err := tumblebug.DeleteResource(o.Namespace, client.RESOURCE_VNET, name)
+				return err
+			}) {
+				return
+			}
 			app.ValidateError(c, func() error {
-				if o.Namespace == "" {
-					return fmt.Errorf("Namespace is required.")
+				objects := []string{"vpc/" + o.Name}
+				if o.Name == "" { // all objects of the kind
+					var err error
+					if objects, err = fnObjects("vpc", func() (app.ListObject, error) {
+						return tumblebug.ListVNets(o.Namespace)
+					}); err != nil {
+						return err
+					}
 				}
-				url := fmt.Sprintf("%s/ns/%s/resources/vNet", app.Config.GetCurrentContext().Urls.Tumblebug, o.Namespace)
-				if o.Name != "" {
-					url += "/" + o.Name
-				}
-				http := resty.New().SetDisableWarn(true).R().SetBasicAuth("default", "default")
-				if resp, err := http.Delete(url); err != nil {
+				if ok, err := fnConfirm(o.Namespace, objects...); !ok {
 					return err
-				} else {
-					o.WriteBody(resp.Body())
 				}
-				return nil
+				return fnWrite(tumblebug.DeleteResource(o.Namespace, client.RESOURCE_VNET, o.Name))
 			}())
 		},
 	})
 
 	// security group
 	cmds.AddCommand(&cobra.Command{
-		Use:   "sg (NAME | --name NAME) [options]",
+		Use:   "sg (NAME | PATTERN | --all | --selector SELECTOR) [options]",
 		Short: "Delete Security Groups.",
 		Args:  app.BindCommandArgs(&o.Name),
 		Run: func(c *cobra.Command, args []string) {
+			tumblebug := client.NewTumblebug(app.Config.GetCurrentContext())
+			app.ValidateError(c, fnNamespace())
+			if fnBulk(c, "sg", o.Namespace, func() (app.ListObject, error) {
+				return tumblebug.ListSecurityGroups(o.Namespace)
+			}, func(name string) error {
+				_, err := tumblebug.DeleteResource(o.Namespace, client.RESOURCE_SECURITY_GROUP, name)
+				return err
+			}) {
+				return
+			}
 			app.ValidateError(c, func() error {
-				if o.Namespace == "" {
-					return fmt.Errorf("Namespace is required.")
-				}
-				url := fmt.Sprintf("%s/ns/%s/resources/securityGroup", app.Config.GetCurrentContext().Urls.Tumblebug, o.Namespace)
-				if o.Name != "" {
-					url += "/" + o.Name
+				objects := []string{"sg/" + o.Name}
+				if o.Name == "" { // all objects of the kind
+					var err error
+					if objects, err = fnObjects("sg", func() (app.ListObject, error) {
+						return tumblebug.ListSecurityGroups(o.Namespace)
+					}); err != nil {
+						return err
+					}
 				}
-				http := resty.New().SetDisableWarn(true).R().SetBasicAuth("default", "default")
-				if resp, err := http.Delete(url); err != nil {
+				if ok, err := fnConfirm(o.Namespace, objects...); !ok {
 					return err
-				} else {
-					o.WriteBody(resp.Body())
 				}
-				return nil
+				return fnWrite(tumblebug.DeleteResource(o.Namespace, client.RESOURCE_SECURITY_GROUP, o.Name))
 			}())
 		},
 	})
 
 	// ssh-key
 	cmds.AddCommand(&cobra.Command{
-		Use:   "sshkey (NAME | --name NAME) [options]",
+		Use:   "sshkey (NAME | PATTERN | --all | --selector SELECTOR) [options]",
 		Short: "Delete SSH-Keys.",
 		Args:  app.BindCommandArgs(&o.Name),
 		Run: func(c *cobra.Command, args []string) {
+			tumblebug := client.NewTumblebug(app.Config.GetCurrentContext())
+			app.ValidateError(c, fnNamespace())
+			if fnBulk(c, "sshkey", o.Namespace, func() (app.ListObject, error) {
+				return tumblebug.ListSshKeys(o.Namespace)
+			}, func(name string) error {
+				_, err := tumblebug.DeleteResource(o.Namespace, client.RESOURCE_SSHKEY, name)
+				return err
+			}) {
+				return
+			}
 			app.ValidateError(c, func() error {
-				if o.Namespace == "" {
-					return fmt.Errorf("Namespace is required.")
-				}
-				url := fmt.Sprintf("%s/ns/%s/resources/sshKey", app.Config.GetCurrentContext().Urls.Tumblebug, o.Namespace)
-				if o.Name != "" {
-					url += "/" + o.Name
+				objects := []string{"sshkey/" + o.Name}
+				if o.Name == "" { // all objects of the kind
+					var err error
+					if objects, err = fnObjects("sshkey", func() (app.ListObject, error) {
+						return tumblebug.ListSshKeys(o.Namespace)
+					}); err != nil {
+						return err
+					}
 				}
-				http := resty.New().SetDisableWarn(true).R().SetBasicAuth("default", "default")
-				if resp, err := http.Delete(url); err != nil {
+				if ok, err := fnConfirm(o.Namespace, objects...); !ok {
 					return err
-				} else {
-					o.WriteBody(resp.Body())
 				}
-				return nil
+				return fnWrite(tumblebug.DeleteResource(o.Namespace, client.RESOURCE_SSHKEY, o.Name))
 			}())
 		},
 	})
 
 	// images
 	cmds.AddCommand(&cobra.Command{
-		Use:   "image (NAME | --name NAME) [options]",
+		Use:   "image (NAME | PATTERN | --all | --selector SELECTOR) [options]",
 		Short: "Delete images.",
 		Args:  app.BindCommandArgs(&o.Name),
 		Run: func(c *cobra.Command, args []string) {
+			tumblebug := client.NewTumblebug(app.Config.GetCurrentContext())
+			app.ValidateError(c, fnNamespace())
+			if fnBulk(c, "image", o.Namespace, func() (app.ListObject, error) {
+				return tumblebug.ListImages(o.Namespace)
+			}, func(name string) error {
+				_, err := tumblebug.DeleteResource(o.Namespace, client.RESOURCE_IMAGE, name)
+				return err
+			}) {
+				return
+			}
 			app.ValidateError(c, func() error {
-				if o.Namespace == "" {
-					return fmt.Errorf("Namespace is required.")
-				}
-				url := fmt.Sprintf("%s/ns/%s/resources/image", app.Config.GetCurrentContext().Urls.Tumblebug, o.Namespace)
-				if o.Name != "" {
-					url += "/" + o.Name
+				objects := []string{"image/" + o.Name}
+				if o.Name == "" { // all objects of the kind
+					var err error
+					if objects, err = fnObjects("image", func() (app.ListObject, error) {
+						return tumblebug.ListImages(o.Namespace)
+					}); err != nil {
+						return err
+					}
 				}
-				http := resty.New().SetDisableWarn(true).R().SetBasicAuth("default", "default")
-				if resp, err := http.Delete(url); err != nil {
+				if ok, err := fnConfirm(o.Namespace, objects...); !ok {
 					return err
-				} else {
-					o.WriteBody(resp.Body())
 				}
-				return nil
+				return fnWrite(tumblebug.DeleteResource(o.Namespace, client.RESOURCE_IMAGE, o.Name))
 			}())
 		},
 	})
 
 	// spec
 	cmds.AddCommand(&cobra.Command{
-		Use:   "spec (NAME | --name NAME) [options]",
+		Use:   "spec (NAME | PATTERN | --all | --selector SELECTOR) [options]",
 		Short: "Delete VM Specifications.",
 		Args:  app.BindCommandArgs(&o.Name),
 		Run: func(c *cobra.Command, args []string) {
+			tumblebug := client.NewTumblebug(app.Config.GetCurrentContext())
+			app.ValidateError(c, fnNamespace())
+			if fnBulk(c, "spec", o.Namespace, func() (app.ListObject, error) {
+				return tumblebug.ListSpecs(o.Namespace)
+			}, func(name string) error {
+				_, err := tumblebug.DeleteResource(o.Namespace, client.RESOURCE_SPEC, name)
+				return err
+			}) {
+				return
+			}
 			app.ValidateError(c, func() error {
-				if o.Namespace == "" {
-					return fmt.Errorf("Namespace is required.")
+				objects := []string{"spec/" + o.Name}
+				if o.Name == "" { // all objects of the kind
+					var err error
+					if objects, err = fnObjects("spec", func() (app.ListObject, error) {
+						return tumblebug.ListSpecs(o.Namespace)
+					}); err != nil {
+						return err
+					}
 				}
-				url := fmt.Sprintf("%s/ns/%s/resources/spec", app.Config.GetCurrentContext().Urls.Tumblebug, o.Namespace)
-				if o.Name != "" {
-					url += "/" + o.Name
-				}
-				http := resty.New().SetDisableWarn(true).R().SetBasicAuth("default", "default")
-				if resp, err := http.Delete(url); err != nil {
+				if ok, err := fnConfirm(o.Namespace, objects...); !ok {
 					return err
-				} else {
-					o.WriteBody(resp.Body())
 				}
-				return nil
+				return fnWrite(tumblebug.DeleteResource(o.Namespace, client.RESOURCE_SPEC, o.Name))
 			}())
 		},
 	})
 
 	// mcis
 	cmds.AddCommand(&cobra.Command{
-		Use:                   "mcis (NAME | --name NAME) [options]",
+		Use:                   "mcis (NAME | PATTERN | --all | --selector SELECTOR) [options]",
 		Short:                 "Delete a MCIS.",
 		Args:                  app.BindCommandArgs(&o.Name),
 		DisableFlagsInUseLine: true,
 		Run: func(c *cobra.Command, args []string) {
+			tumblebug := client.NewTumblebug(app.Config.GetCurrentContext())
+			app.ValidateError(c, fnNamespace())
+			if fnBulk(c, "mcis", o.Namespace, func() (app.ListObject, error) {
+				return tumblebug.ListMCIS(o.Namespace)
+			}, func(name string) error {
+				for _, action := range []string{"terminate", "refine", ""} {
+					if _, err := tumblebug.DeleteMCIS(o.Namespace, name, action); err != nil {
+						return err
+					}
+				}
+				return nil
+			}) {
+				return
+			}
 			app.ValidateError(c, fnValidate())
 			app.ValidateError(c, func() error {
-				url := fmt.Sprintf("%s/ns/%s/mcis/%s?action=terminate", app.Config.GetCurrentContext().Urls.Tumblebug, o.Namespace, o.Name)
-				http := resty.New().SetDisableWarn(true).R().SetBasicAuth("default", "default")
-				if resp, err := http.Delete(url + "?action=terminate"); err != nil {
+				if ok, err := fnConfirm(o.Namespace, "mcis/"+o.Name); !ok {
 					return err
-				} else {
-					o.WriteBody(resp.Body())
 				}
-				if resp, err := http.Delete(url + "?action=refine"); err != nil {
-					return err
-				} else {
-					o.WriteBody(resp.Body())
-				}
-				if resp, err := http.Delete(url); err != nil {
-					return err
-				} else {
-					o.WriteBody(resp.Body())
+				for _, action := range []string{"terminate", "refine", ""} {
+					if err := fnWrite(tumblebug.DeleteMCIS(o.Namespace, o.Name, action)); err != nil {
+						return err
+					}
 				}
 				return nil
 			}())
@@ -0,0 +1,384 @@
+package describe
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/itnpeople/cbctl/app"
+	"github.com/itnpeople/cbctl/client"
+	"github.com/itnpeople/cbctl/utils"
+)
+
+// a struct to support command
+type DescribeOptions struct {
+	*app.Options
+	Cluster string
+}
+
+// validates
+func (o *DescribeOptions) Validate() error {
+	o.Namespace = utils.NVL(o.Namespace, app.Config.GetCurrentContext().Namespace)
+	if o.Namespace == "" {
+		return fmt.Errorf("Namespace is required.")
+	}
+	if o.Name == "" {
+		return fmt.Errorf("Name is required.")
+	}
+	return nil
+}
+
+// a group of nodes (or vms) of a connection and a spec
+type group struct {
+	Connection string
+	Spec       string
+	Rows       [][]string
+}
+
+// groups rows by a connection and a spec (in order of names)
+func groupBy(groups []*group, connection string, spec string, row []string) []*group {
+	for _, g := range groups {
+		if g.Connection == connection && g.Spec == spec {
+			g.Rows = append(g.Rows, row)
+			return groups
+		}
+	}
+	return append(groups, &group{Connection: connection, Spec: spec, Rows: [][]string{row}})
+}
+
+func sortGroups(groups []*group) {
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].Connection != groups[j].Connection {
+			return groups[i].Connection < groups[j].Connection
+		}
+		return groups[i].Spec < groups[j].Spec
+	})
+}
+
+// writes groups of nodes (or vms)
+func writeGroups(w *writer, level int, title string, columns []string, groups []*group) {
+	count := 0
+	for _, g := range groups {
+		count += len(g.Rows)
+	}
+	w.Section(level, "%s (%d):", title, count)
+	if len(groups) == 0 {
+		w.Section(level+1, "<none>")
+	}
+	sortGroups(groups)
+	for _, g := range groups {
+		w.Section(level+1, "%s / %s (%d):", app.None(g.Connection), app.None(g.Spec), len(g.Rows))
+		w.Table(level+2, columns, g.Rows)
+	}
+}
+
+// writes related spider connections (and errors of connections not found or given errors)
+func writeConnections(w *writer, level int, names []string, errs [][]string) {
+
+	w.Section(level, "Connections:")
+	spider := client.NewSpider(app.Config.GetCurrentContext())
+	rows := [][]string{}
+	sort.Strings(names)
+	for _, name := range names {
+		conn, err := spider.GetConnection(name)
+		if err != nil {
+			rows = append(rows, []string{name, "<error>", "<none>", "<none>", "<none>", "<none>", "<none>"})
+			errs = append(errs, []string{name, errorMessage(err)})
+			continue
+		}
+		region, zone := "", ""
+		if r, err := spider.GetRegion(conn.RegionName); err == nil {
+			region, zone = regionOf(r)
+		}
+		rows = append(rows, []string{conn.ConfigName, app.None(conn.ProviderName), app.None(conn.DriverName), app.None(conn.CredentialName), app.None(conn.RegionName), app.None(region), app.None(zone)})
+	}
+	w.Table(level+1, []string{"NAME", "PROVIDER", "DRIVER", "CREDENTIAL", "REGION", "CSP-REGION", "CSP-ZONE"}, rows)
+	if len(errs) > 0 {
+		w.Section(level, "Errors:")
+		for _, e := range errs {
+			w.Field(level+1, e[0], e[1])
+		}
+	}
+}
+
+// returns a region (a location of azure) and a zone of a spider region
+func regionOf(r *client.Region) (string, string) {
+	l := r.Location()
+	return l.Region, l.Zone
+}
+
+// returns a message of an error (an error message of a service if it is an api error)
+func errorMessage(err error) string {
+	var e *client.APIError
+	if errors.As(err, &e) {
+		return e.Message
+	}
+	return err.Error()
+}
+
+// returns connections of nodes by a node name (resolved by locations of connections, "" and an error if not identified)
+func nodeConnections(nodes []client.Node) (map[string]string, [][]string) {
+	connections, errs := map[string]string{}, [][]string{}
+	locations, err := client.NewSpider(app.Config.GetCurrentContext()).ConnectionLocations()
+	if err != nil {
+		locations = map[string]client.Location{}
+		errs = append(errs, []string{"<connections>", errorMessage(err)})
+	}
+	for _, node := range nodes {
+		if name, err := client.ResolveConnection(node, locations); err != nil {
+			errs = append(errs, []string{"node/" + node.Name, err.Error()})
+		} else {
+			connections[node.Name] = name
+		}
+	}
+	return connections, errs
+}
+
+// returns distinct non-empty values
+func distinct(values []string) []string {
+	set := map[string]bool{}
+	list := []string{}
+	for _, v := range values {
+		if v != "" && !set[v] {
+			set[v] = true
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+// describes a cluster (status, kubernetes config, nodes grouped by connection and spec, connections)
+func (o *DescribeOptions) describeCluster() error {
+
+	cluster, err := client.NewMCKS(app.Config.GetCurrentContext()).GetCluster(o.Namespace, o.Name)
+	if err != nil {
+		return err
+	}
+
+	w := newWriter(o.OutStream)
+	w.Field(0, "Name", cluster.Name)
+	w.Field(0, "Namespace", o.Namespace)
+	w.Field(0, "Status", cluster.Status)
+	w.Field(0, "Created", fmt.Sprintf("%s (%s)", app.None(cluster.CreatedTime), app.Age(cluster.CreatedTime)))
+	w.Field(0, "Label", cluster.Label)
+	w.Field(0, "Description", cluster.Description)
+	k := cluster.Config.Kubernetes
+	w.Section(0, "Kubernetes:")
+	w.Field(1, "Version", k.Version)
+	w.Field(1, "Control-plane Leader", cluster.CpLeader)
+	w.Field(1, "Monitoring Agent", cluster.InstallMonAgent)
+	w.Section(1, "Networking:")
+	w.Field(2, "Network CNI", utils.NVL(k.NetworkCni, cluster.NetworkCni))
+	w.Field(2, "Pod CIDR", k.PodCidr)
+	w.Field(2, "Service CIDR", k.ServiceCidr)
+	w.Field(2, "Service DNS Domain", k.ServiceDnsDomain)
+
+	columns := []string{"NAME", "PUBLIC-IP", "STATUS", "CSP", "REGION", "ZONE", "AGE"}
+	controlPlanes, workers := []*group{}, []*group{}
+	byNode, errs := nodeConnections(cluster.Nodes)
+	connections := []string{}
+	for _, node := range cluster.Nodes {
+		row := []string{node.Name, app.None(node.PublicIP), app.None(node.Status), app.None(node.Csp), app.None(node.RegionLabel), app.None(node.ZoneLabel), app.Age(node.CreatedTime)}
+		connection := byNode[node.Name]
+		if node.Role == client.ROLE_CONTROL_PLANE {
+			controlPlanes = groupBy(controlPlanes, connection, node.Spec, row)
+		} else {
+			workers = groupBy(workers, connection, node.Spec, row)
+		}
+		connections = append(connections, connection)
+	}
+	writeGroups(w, 0, "Control Plane", columns, controlPlanes)
+	writeGroups(w, 0, "Workers", columns, workers)
+	writeConnections(w, 0, distinct(connections), errs)
+	w.Flush()
+
+	return nil
+}
+
+// describes a node
+func (o *DescribeOptions) describeNode() error {
+
+	if o.Cluster == "" {
+		return fmt.Errorf("Cluster name is required.")
+	}
+	node, err := client.NewMCKS(app.Config.GetCurrentContext()).GetNode(o.Namespace, o.Cluster, o.Name)
+	if err != nil {
+		return err
+	}
+
+	byNode, errs := nodeConnections([]client.Node{*node})
+
+	w := newWriter(o.OutStream)
+	w.Field(0, "Name", node.Name)
+	w.Field(0, "Cluster", o.Cluster)
+	w.Field(0, "Namespace", o.Namespace)
+	w.Field(0, "Role", node.Role)
+	w.Field(0, "Status", node.Status)
+	w.Field(0, "Created", fmt.Sprintf("%s (%s)", app.None(node.CreatedTime), app.Age(node.CreatedTime)))
+	w.Field(0, "Connection", byNode[node.Name])
+	w.Field(0, "Spec", node.Spec)
+	w.Field(0, "Public IP", node.PublicIP)
+	w.Field(0, "UID", node.UID)
+	w.Section(0, "Labels:")
+	w.Field(1, "CSP", node.CspLabel)
+	w.Field(1, "Region", node.RegionLabel)
+	w.Field(1, "Zone", node.ZoneLabel)
+	writeConnections(w, 0, distinct([]string{byNode[node.Name]}), errs)
+	w.Flush()
+
+	return nil
+}
+
+// describes a connection (a driver, a credential without secrets and a region)
+func (o *DescribeOptions) describeConnection() error {
+
+	spider := client.NewSpider(app.Config.GetCurrentContext())
+	conn, err := spider.GetConnection(o.Name)
+	if err != nil {
+		return err
+	}
+
+	w := newWriter(o.OutStream)
+	w.Field(0, "Name", conn.ConfigName)
+	w.Field(0, "Provider", conn.ProviderName)
+	w.Section(0, "Driver:")
+	if driver, err := spider.GetDriver(conn.DriverName); err != nil {
+		w.Field(1, "Name", conn.DriverName)
+		w.Field(1, "Error", errorMessage(err))
+	} else {
+		w.Field(1, "Name", driver.DriverName)
+		w.Field(1, "Library", driver.DriverLibFileName)
+	}
+	w.Section(0, "Credential:")
+	w.Field(1, "Name", conn.CredentialName)
+	if credential, err := spider.GetCredential(conn.CredentialName); err != nil {
+		w.Field(1, "Error", errorMessage(err))
+	} else {
+		for _, kv := range credential.Masked().KeyValueInfoList {
+			if kv.Value != "" {
+				w.Field(1, kv.Key, kv.Value)
+			}
+		}
+	}
+	w.Section(0, "Region:")
+	w.Field(1, "Name", conn.RegionName)
+	if region, err := spider.GetRegion(conn.RegionName); err != nil {
+		w.Field(1, "Error", errorMessage(err))
+	} else {
+		for _, kv := range region.KeyValueInfoList {
+			if kv.Value != "" {
+				w.Field(1, kv.Key, kv.Value)
+			}
+		}
+	}
+	w.Flush()
+
+	return nil
+}
+
+// describes a MCIS (vms grouped by connection and spec, connections)
+func (o *DescribeOptions) describeMCIS() error {
+
+	mcis, err := client.NewTumblebug(app.Config.GetCurrentContext()).GetMCIS(o.Namespace, o.Name)
+	if err != nil {
+		return err
+	}
+
+	w := newWriter(o.OutStream)
+	w.Field(0, "Name", mcis.Name)
+	w.Field(0, "Id", mcis.Id)
+	w.Field(0, "Namespace", o.Namespace)
+	w.Field(0, "Status", mcis.Status)
+	w.Field(0, "Target Status", mcis.TargetStatus)
+	w.Field(0, "Target Action", mcis.TargetAction)
+	w.Field(0, "Monitoring Agent", mcis.InstallMonAgent)
+	w.Field(0, "Label", mcis.Label)
+	w.Field(0, "Description", mcis.Description)
+
+	vms := []*group{}
+	connections := []string{}
+	for _, vm := range mcis.VM {
+		vms = groupBy(vms, vm.ConnectionName, vm.SpecId, []string{vm.Name, app.None(vm.PublicIP), app.None(vm.PrivateIP), app.None(vm.Status), app.None(vm.ImageId)})
+		connections = append(connections, vm.ConnectionName)
+	}
+	writeGroups(w, 0, "VMs", []string{"NAME", "PUBLIC-IP", "PRIVATE-IP", "STATUS", "IMAGE"}, vms)
+	writeConnections(w, 0, distinct(connections), [][]string{})
+	w.Flush()
+
+	return nil
+}
+
+// returns a cobra command
+func NewCommandDescribe(options *app.Options) *cobra.Command {
+
+	o := &DescribeOptions{
+		Options: options,
+	}
+
+	cmds := &cobra.Command{
+		Use:                   "describe",
+		Short:                 "Show details of a object",
+		DisableFlagsInUseLine: true,
+		Run: func(c *cobra.Command, args []string) {
+			c.Help()
+		},
+	}
+
+	// cluster
+	cmds.AddCommand(&cobra.Command{
+		Use:                   "cluster (NAME | --name NAME) [options]",
+		Short:                 "Show details of a cluster (kubernetes config, nodes and connections)",
+		Args:                  app.BindCommandArgs(&o.Name),
+		DisableFlagsInUseLine: true,
+		Run: func(c *cobra.Command, args []string) {
+			app.ValidateError(c, o.Validate())
+			app.ValidateError(c, o.describeCluster())
+		},
+	})
+
+	// node
+	cmdNode := &cobra.Command{
+		Use:                   "node (NAME | --name NAME) --cluster CLUSTER [options]",
+		Short:                 "Show details of a node",
+		Args:                  app.BindCommandArgs(&o.Name),
+		DisableFlagsInUseLine: true,
+		Run: func(c *cobra.Command, args []string) {
+			app.ValidateError(c, o.Validate())
+			app.ValidateError(c, o.describeNode())
+		},
+	}
+	cmdNode.Flags().StringVar(&o.Cluster, "cluster", "", "Name of cluster")
+	cmds.AddCommand(cmdNode)
+
+	// connection
+	cmds.AddCommand(&cobra.Command{
+		Use:                   "connection (NAME | --name NAME) [options]",
+		Short:                 "Show details of a cloud connection (driver, credential and region)",
+		Args:                  app.BindCommandArgs(&o.Name),
+		DisableFlagsInUseLine: true,
+		Run: func(c *cobra.Command, args []string) {
+			app.ValidateError(c, func() error {
+				if o.Name == "" {
+					return fmt.Errorf("Name is required.")
+				}
+				return o.describeConnection()
+			}())
+		},
+	})
+
+	// mcis
+	cmds.AddCommand(&cobra.Command{
+		Use:                   "mcis (NAME | --name NAME) [options]",
+		Short:                 "Show details of a MCIS (vms and connections)",
+		Args:                  app.BindCommandArgs(&o.Name),
+		DisableFlagsInUseLine: true,
+		Run: func(c *cobra.Command, args []string) {
+			app.ValidateError(c, o.Validate())
+			app.ValidateError(c, o.describeMCIS())
+		},
+	})
+
+	return cmds
+}
@@ -0,0 +1,48 @@
+package describe
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/itnpeople/cbctl/app"
+)
+
+const INDENT = "  "
+
+// a writer of a human-readable view (aligns fields and tables of same levels)
+type writer struct {
+	w *tabwriter.Writer
+}
+
+func newWriter(out io.Writer) *writer {
+	return &writer{w: tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)}
+}
+
+// writes "name:  value" ("<none>" if the value is empty)
+func (w *writer) Field(level int, name string, value string) {
+	fmt.Fprintf(w.w, "%s%s:\t%s\n", strings.Repeat(INDENT, level), name, app.None(value))
+}
+
+// writes a section title (eg. "Workers (2):")
+func (w *writer) Section(level int, format string, params ...interface{}) {
+	fmt.Fprintf(w.w, "%s%s\n", strings.Repeat(INDENT, level), fmt.Sprintf(format, params...))
+}
+
+// writes a table (a "<none>" line if rows are empty)
+func (w *writer) Table(level int, columns []string, rows [][]string) {
+	indent := strings.Repeat(INDENT, level)
+	if len(rows) == 0 {
+		fmt.Fprintf(w.w, "%s<none>\n", indent)
+		return
+	}
+	fmt.Fprintf(w.w, "%s%s\n", indent, strings.Join(columns, "\t"))
+	for _, row := range rows {
+		fmt.Fprintf(w.w, "%s%s\n", indent, strings.Join(row, "\t"))
+	}
+}
+
+func (w *writer) Flush() {
+	w.w.Flush()
+}
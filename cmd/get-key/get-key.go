@@ -3,10 +3,10 @@ package getkey
 import (
 	"fmt"
 
-	"github.com/go-resty/resty/v2"
 	"github.com/spf13/cobra"
 
 	"github.com/itnpeople/cbctl/app"
+	"github.com/itnpeople/cbctl/client"
 	"github.com/itnpeople/cbctl/utils"
 )
 
@@ -35,14 +35,10 @@ func NewCommandGetKey(o *app.Options) *cobra.Command {
 				}
 
 				// execute
-				res := &struct {
-					Credential string `json:"credential"`
-				}{}
-				url := fmt.Sprintf("%s/ns/%s/clusters/%s/nodes/%s", app.Config.GetCurrentContext().Urls.MCKS, o.Namespace, clusterName, o.Name)
-				if _, err := resty.New().SetDisableWarn(true).R().SetResult(res).Get(url); err != nil {
+				if node, err := client.NewMCKS(app.Config.GetCurrentContext()).GetNode(o.Namespace, clusterName, o.Name); err != nil {
 					return err
 				} else {
-					o.OutStream.WriteString(res.Credential)
+					o.OutStream.WriteString(node.Credential)
 				}
 				return nil
 			}())
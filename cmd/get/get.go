@@ -2,11 +2,12 @@ package get
 
 import (
 	"fmt"
+	"time"
 
-	"github.com/go-resty/resty/v2"
 	"github.com/spf13/cobra"
 
 	"github.com/itnpeople/cbctl/app"
+	"github.com/itnpeople/cbctl/client"
 	"github.com/itnpeople/cbctl/utils"
 )
 
@@ -22,6 +23,20 @@ func NewCommandGet(o *app.Options) *cobra.Command {
 		return nil
 	}
 
+	// write a object (or watch changes)
+	var watch bool
+	var interval time.Duration
+	fnRun := func(fetch func() (interface{}, error)) error {
+		if watch {
+			return o.Watch(interval, fetch)
+		}
+		obj, err := fetch()
+		if err != nil {
+			return err
+		}
+		return o.WriteObject(obj)
+	}
+
 	// Get command
 	cmds := &cobra.Command{
 		Use:                   "get",
@@ -31,6 +46,8 @@ func NewCommandGet(o *app.Options) *cobra.Command {
 			c.Help()
 		},
 	}
+	cmds.PersistentFlags().BoolVarP(&watch, "watch", "w", false, "Watch for changes")
+	cmds.PersistentFlags().DurationVar(&interval, "interval", 5*time.Second, "Polling interval of watch")
 
 	// get cluster command
 	cmds.AddCommand(&cobra.Command{
@@ -40,18 +57,13 @@ func NewCommandGet(o *app.Options) *cobra.Command {
 		Args:                  app.BindCommandArgs(&o.Name),
 		Run: func(c *cobra.Command, args []string) {
 			app.ValidateError(c, fnValidate())
-			app.ValidateError(c, func() error {
-				url := fmt.Sprintf("%s/ns/%s/clusters", app.Config.GetCurrentContext().Urls.MCKS, o.Namespace)
-				if o.Name != "" {
-					url = url + "/" + o.Name
-				}
-				if resp, err := resty.New().SetDisableWarn(true).R().Get(url); err != nil {
-					return err
-				} else {
-					o.WriteBody(resp.Body())
+			app.ValidateError(c, fnRun(func() (interface{}, error) {
+				mcks := client.NewMCKS(app.Config.GetCurrentContext())
+				if o.Name == "" {
+					return mcks.ListClusters(o.Namespace)
 				}
-				return nil
-			}())
+				return mcks.GetCluster(o.Namespace, o.Name)
+			}))
 		},
 	})
 
@@ -64,21 +76,16 @@ func NewCommandGet(o *app.Options) *cobra.Command {
 		DisableFlagsInUseLine: true,
 		Run: func(c *cobra.Command, args []string) {
 			app.ValidateError(c, fnValidate())
-			app.ValidateError(c, func() error {
+			app.ValidateError(c, fnRun(func() (interface{}, error) {
 				if clusterName == "" {
-					return fmt.Errorf("Cluster name is required.")
-				}
-				url := fmt.Sprintf("%s/ns/%s/clusters/%s/nodes", app.Config.GetCurrentContext().Urls.MCKS, o.Namespace, clusterName)
-				if o.Name != "" {
-					url = url + "/" + o.Name
+					return nil, fmt.Errorf("Cluster name is required.")
 				}
-				if resp, err := resty.New().SetDisableWarn(true).R().Get(url); err != nil {
-					return err
-				} else {
-					o.WriteBody(resp.Body())
+				mcks := client.NewMCKS(app.Config.GetCurrentContext())
+				if o.Name == "" {
+					return mcks.ListNodes(o.Namespace, clusterName)
 				}
-				return nil
-			}())
+				return mcks.GetNode(o.Namespace, clusterName, o.Name)
+			}))
 		},
 	}
 	cmdNode.Flags().StringVar(&clusterName, "cluster", "", "Name of cluster")
@@ -92,20 +99,15 @@ func NewCommandGet(o *app.Options) *cobra.Command {
 		Args:                  app.BindCommandArgs(&o.Name),
 		DisableFlagsInUseLine: true,
 		Run: func(c *cobra.Command, args []string) {
-			app.ValidateError(c, func() error {
-				url := fmt.Sprintf("%s/driver", app.Config.GetCurrentContext().Urls.Spider)
+			app.ValidateError(c, fnRun(func() (interface{}, error) {
+				spider := client.NewSpider(app.Config.GetCurrentContext())
 				if o.Name != "" {
-					url = fmt.Sprintf("%s/%s", url, o.Name)
+					return spider.GetDriver(o.Name)
 				} else if csp != "" {
-					url = fmt.Sprintf("%s/%s-driver-v1.0", url, csp)
+					return spider.GetDriver(client.DriverName(csp))
 				}
-				if resp, err := resty.New().SetDisableWarn(true).R().Get(url); err != nil {
-					return err
-				} else {
-					o.WriteBody(resp.Body())
-				}
-				return nil
-			}())
+				return spider.ListDrivers()
+			}))
 		},
 	}
 	cmdDrv.Flags().StringVar(&csp, "csp", "", "Cloud service provider (aws, gcp, azure, alibaba, tencent, ibm, openstack, cloudit)")
@@ -118,42 +120,43 @@ func NewCommandGet(o *app.Options) *cobra.Command {
 		Args:                  app.BindCommandArgs(&o.Name),
 		DisableFlagsInUseLine: true,
 		Run: func(c *cobra.Command, args []string) {
-			app.ValidateError(c, func() error {
-				url := fmt.Sprintf("%s/region", app.Config.GetCurrentContext().Urls.Spider)
-				if o.Name != "" {
-					url += "/" + o.Name
+			app.ValidateError(c, fnRun(func() (interface{}, error) {
+				spider := client.NewSpider(app.Config.GetCurrentContext())
+				if o.Name == "" {
+					return spider.ListRegions()
 				}
-				if resp, err := resty.New().SetDisableWarn(true).R().Get(url); err != nil {
-					return err
-				} else {
-					o.WriteBody(resp.Body())
-				}
-				return nil
-			}())
+				return spider.GetRegion(o.Name)
+			}))
 		},
 	})
 
 	// credential
-	cmds.AddCommand(&cobra.Command{
+	var showSecrets bool
+	cmdCred := &cobra.Command{
 		Use:                   "credential (NAME | --name NAME) [options]",
 		Short:                 "Get a cloud credential",
 		Args:                  app.BindCommandArgs(&o.Name),
 		DisableFlagsInUseLine: true,
 		Run: func(c *cobra.Command, args []string) {
-			app.ValidateError(c, func() error {
-				url := fmt.Sprintf("%s/credential", app.Config.GetCurrentContext().Urls.Spider)
-				if o.Name != "" {
-					url += "/" + o.Name
+			app.ValidateError(c, fnRun(func() (interface{}, error) {
+				spider := client.NewSpider(app.Config.GetCurrentContext())
+				if o.Name == "" {
+					res, err := spider.ListCredentials()
+					if err != nil || showSecrets {
+						return res, err
+					}
+					return res.Masked(), nil
 				}
-				if resp, err := resty.New().SetDisableWarn(true).R().Get(url); err != nil {
-					return err
-				} else {
-					o.WriteBody(resp.Body())
+				res, err := spider.GetCredential(o.Name)
+				if err != nil || showSecrets {
+					return res, err
 				}
-				return nil
-			}())
+				return res.Masked(), nil
+			}))
 		},
-	})
+	}
+	cmdCred.Flags().BoolVar(&showSecrets, "show-secrets", false, "Show secret values (ClientSecret, PrivateKey, Password, ApiKey, AuthToken)")
+	cmds.AddCommand(cmdCred)
 
 	// connection info.
 	cmds.AddCommand(&cobra.Command{
@@ -162,18 +165,13 @@ func NewCommandGet(o *app.Options) *cobra.Command {
 		Args:                  app.BindCommandArgs(&o.Name),
 		DisableFlagsInUseLine: true,
 		Run: func(c *cobra.Command, args []string) {
-			app.ValidateError(c, func() error {
-				url := fmt.Sprintf("%s/connectionconfig", app.Config.GetCurrentContext().Urls.Spider)
-				if o.Name != "" {
-					url += "/" + o.Name
+			app.ValidateError(c, fnRun(func() (interface{}, error) {
+				spider := client.NewSpider(app.Config.GetCurrentContext())
+				if o.Name == "" {
+					return spider.ListConnections()
 				}
-				if resp, err := resty.New().SetDisableWarn(true).R().Get(url); err != nil {
-					return err
-				} else {
-					o.WriteBody(resp.Body())
-				}
-				return nil
-			}())
+				return spider.GetConnection(o.Name)
+			}))
 		},
 	})
 
@@ -184,19 +182,13 @@ func NewCommandGet(o *app.Options) *cobra.Command {
 		Args:                  app.BindCommandArgs(&o.Name),
 		DisableFlagsInUseLine: true,
 		Run: func(c *cobra.Command, args []string) {
-			app.ValidateError(c, func() error {
-				url := fmt.Sprintf("%s/ns", app.Config.GetCurrentContext().Urls.Tumblebug)
-				if o.Name != "" {
-					url += "/" + o.Name
+			app.ValidateError(c, fnRun(func() (interface{}, error) {
+				tumblebug := client.NewTumblebug(app.Config.GetCurrentContext())
+				if o.Name == "" {
+					return tumblebug.ListNamespaces()
 				}
-				http := resty.New().SetDisableWarn(true).R().SetBasicAuth("default", "default")
-				if resp, err := http.Get(url); err != nil {
-					return err
-				} else {
-					o.WriteBody(resp.Body())
-				}
-				return nil
-			}())
+				return tumblebug.GetNamespace(o.Name)
+			}))
 		},
 	})
 
@@ -207,20 +199,13 @@ func NewCommandGet(o *app.Options) *cobra.Command {
 		Args:  app.BindCommandArgs(&o.Name),
 		Run: func(c *cobra.Command, args []string) {
 			app.ValidateError(c, fnValidate())
-			app.ValidateError(c, func() error {
-				url := fmt.Sprintf("%s/ns/%s/resources/vNet", app.Config.GetCurrentContext().Urls.Tumblebug, o.Namespace)
-				if o.Name != "" {
-					url += "/" + o.Name
+			app.ValidateError(c, fnRun(func() (interface{}, error) {
+				tumblebug := client.NewTumblebug(app.Config.GetCurrentContext())
+				if o.Name == "" {
+					return tumblebug.ListVNets(o.Namespace)
 				}
-				http := resty.New().SetDisableWarn(true).R().SetBasicAuth("default", "default")
-				if resp, err := http.Get(url); err != nil {
-					return err
-				} else {
-					o.WriteBody(resp.Body())
-				}
-
-				return nil
-			}())
+				return tumblebug.GetVNet(o.Namespace, o.Name)
+			}))
 		},
 	})
 
@@ -231,20 +216,13 @@ func NewCommandGet(o *app.Options) *cobra.Command {
 		Args:  app.BindCommandArgs(&o.Name),
 		Run: func(c *cobra.Command, args []string) {
 			app.ValidateError(c, fnValidate())
-			app.ValidateError(c, func() error {
-				url := fmt.Sprintf("%s/ns/%s/resources/securityGroup", app.Config.GetCurrentContext().Urls.Tumblebug, o.Namespace)
-				if o.Name != "" {
-					url += "/" + o.Name
-				}
-				fmt.Println(url)
-				http := resty.New().SetDisableWarn(true).R().SetBasicAuth("default", "default")
-				if resp, err := http.Get(url); err != nil {
-					return err
-				} else {
-					o.WriteBody(resp.Body())
+			app.ValidateError(c, fnRun(func() (interface{}, error) {
+				tumblebug := client.NewTumblebug(app.Config.GetCurrentContext())
+				if o.Name == "" {
+					return tumblebug.ListSecurityGroups(o.Namespace)
 				}
-				return nil
-			}())
+				return tumblebug.GetSecurityGroup(o.Namespace, o.Name)
+			}))
 		},
 	})
 
@@ -255,19 +233,13 @@ func NewCommandGet(o *app.Options) *cobra.Command {
 		Args:  app.BindCommandArgs(&o.Name),
 		Run: func(c *cobra.Command, args []string) {
 			app.ValidateError(c, fnValidate())
-			app.ValidateError(c, func() error {
-				url := fmt.Sprintf("%s/ns/%s/resources/sshKey", app.Config.GetCurrentContext().Urls.Tumblebug, o.Namespace)
-				if o.Name != "" {
-					url += "/" + o.Name
-				}
-				http := resty.New().SetDisableWarn(true).R().SetBasicAuth("default", "default")
-				if resp, err := http.Get(url); err != nil {
-					return err
-				} else {
-					o.WriteBody(resp.Body())
+			app.ValidateError(c, fnRun(func() (interface{}, error) {
+				tumblebug := client.NewTumblebug(app.Config.GetCurrentContext())
+				if o.Name == "" {
+					return tumblebug.ListSshKeys(o.Namespace)
 				}
-				return nil
-			}())
+				return tumblebug.GetSshKey(o.Namespace, o.Name)
+			}))
 		},
 	})
 
@@ -278,19 +250,13 @@ func NewCommandGet(o *app.Options) *cobra.Command {
 		Args:  app.BindCommandArgs(&o.Name),
 		Run: func(c *cobra.Command, args []string) {
 			app.ValidateError(c, fnValidate())
-			app.ValidateError(c, func() error {
-				url := fmt.Sprintf("%s/ns/%s/resources/image", app.Config.GetCurrentContext().Urls.Tumblebug, o.Namespace)
-				if o.Name != "" {
-					url += "/" + o.Name
-				}
-				http := resty.New().SetDisableWarn(true).R().SetBasicAuth("default", "default")
-				if resp, err := http.Get(url); err != nil {
-					return err
-				} else {
-					o.WriteBody(resp.Body())
+			app.ValidateError(c, fnRun(func() (interface{}, error) {
+				tumblebug := client.NewTumblebug(app.Config.GetCurrentContext())
+				if o.Name == "" {
+					return tumblebug.ListImages(o.Namespace)
 				}
-				return nil
-			}())
+				return tumblebug.GetImage(o.Namespace, o.Name)
+			}))
 		},
 	})
 
@@ -301,19 +267,13 @@ func NewCommandGet(o *app.Options) *cobra.Command {
 		Args:  app.BindCommandArgs(&o.Name),
 		Run: func(c *cobra.Command, args []string) {
 			app.ValidateError(c, fnValidate())
-			app.ValidateError(c, func() error {
-				url := fmt.Sprintf("%s/ns/%s/resources/spec", app.Config.GetCurrentContext().Urls.Tumblebug, o.Namespace)
-				if o.Name != "" {
-					url += "/" + o.Name
-				}
-				http := resty.New().SetDisableWarn(true).R().SetBasicAuth("default", "default")
-				if resp, err := http.Get(url); err != nil {
-					return err
-				} else {
-					o.WriteBody(resp.Body())
+			app.ValidateError(c, fnRun(func() (interface{}, error) {
+				tumblebug := client.NewTumblebug(app.Config.GetCurrentContext())
+				if o.Name == "" {
+					return tumblebug.ListSpecs(o.Namespace)
 				}
-				return nil
-			}())
+				return tumblebug.GetSpec(o.Namespace, o.Name)
+			}))
 		},
 	})
 
@@ -324,20 +284,13 @@ func NewCommandGet(o *app.Options) *cobra.Command {
 		Args:  app.BindCommandArgs(&o.Name),
 		Run: func(c *cobra.Command, args []string) {
 			app.ValidateError(c, fnValidate())
-			app.ValidateError(c, func() error {
-				url := fmt.Sprintf("%s/ns/%s/mcis", app.Config.GetCurrentContext().Urls.Tumblebug, o.Namespace)
-				if o.Name != "" {
-					url += "/" + o.Name
-				}
-				fmt.Println(url)
-				http := resty.New().SetDisableWarn(true).R().SetBasicAuth("default", "default")
-				if resp, err := http.Get(url); err != nil {
-					return err
-				} else {
-					o.WriteBody(resp.Body())
+			app.ValidateError(c, fnRun(func() (interface{}, error) {
+				tumblebug := client.NewTumblebug(app.Config.GetCurrentContext())
+				if o.Name == "" {
+					return tumblebug.ListMCIS(o.Namespace)
 				}
-				return nil
-			}())
+				return tumblebug.GetMCIS(o.Namespace, o.Name)
+			}))
 		},
 	})
 
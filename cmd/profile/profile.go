@@ -0,0 +1,171 @@
+package profile
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/itnpeople/cbctl/app"
+	"github.com/itnpeople/cbctl/client"
+	"github.com/itnpeople/cbctl/utils"
+)
+
+// a struct to support command
+type ProfileOptions struct {
+	*app.Options
+	Cluster string
+	Force   bool
+}
+
+// returns a profile of an existing cluster (node-pools are grouped by a role, a connection and a spec, fails if a connection of a node is not identified)
+func (o *ProfileOptions) fromCluster() (*app.ClusterProfile, error) {
+
+	o.Namespace = utils.NVL(o.Namespace, app.Config.GetCurrentContext().Namespace)
+	if o.Namespace == "" {
+		return nil, fmt.Errorf("Namespace is required.")
+	}
+	cluster, err := client.NewMCKS(app.Config.GetCurrentContext()).GetCluster(o.Namespace, o.Cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	locations, err := client.NewSpider(app.Config.GetCurrentContext()).ConnectionLocations()
+	if err != nil {
+		return nil, err
+	}
+
+	k := cluster.Config.Kubernetes
+	profile := &app.ClusterProfile{Label: cluster.Label, Description: cluster.Description}
+	profile.Config.Kubernetes.Version = k.Version
+	profile.Config.Kubernetes.NetworkCni = utils.NVL(k.NetworkCni, cluster.NetworkCni)
+	profile.Config.Kubernetes.PodCidr = k.PodCidr
+	profile.Config.Kubernetes.ServiceCidr = k.ServiceCidr
+	profile.Config.Kubernetes.ServiceDnsDomain = k.ServiceDnsDomain
+	add := func(pools []app.NodePool, connection string, spec string) []app.NodePool {
+		for i := range pools {
+			if pools[i].Connection == connection && pools[i].Spec == spec {
+				pools[i].Count++
+				return pools
+			}
+		}
+		return append(pools, app.NodePool{Connection: connection, Count: 1, Spec: spec})
+	}
+	for _, node := range cluster.Nodes {
+		connection, err := client.ResolveConnection(node, locations)
+		if err != nil {
+			return nil, &app.ExitError{Code: app.EXIT_ERROR, Message: fmt.Sprintf("Unable to save a profile of cluster/%s : %v", o.Cluster, err)}
+		}
+		if node.Role == client.ROLE_CONTROL_PLANE {
+			profile.ControlPlane = add(profile.ControlPlane, connection, node.Spec)
+		} else {
+			profile.Worker = add(profile.Worker, connection, node.Spec)
+		}
+	}
+	for _, pools := range [][]app.NodePool{profile.ControlPlane, profile.Worker} {
+		sort.SliceStable(pools, func(i, j int) bool {
+			return pools[i].Connection+"/"+pools[i].Spec < pools[j].Connection+"/"+pools[j].Spec
+		})
+	}
+	return profile, nil
+}
+
+// saves a profile of a cluster (--cluster) or a file (-f)
+func (o *ProfileOptions) save() error {
+
+	if o.Name == "" {
+		return fmt.Errorf("Name is required.")
+	}
+	if (o.Cluster == "") == (o.Filename == "") {
+		return fmt.Errorf("Either a cluster (--cluster) or a file (-f) is required.")
+	}
+
+	var profile *app.ClusterProfile
+	var err error
+	if o.Cluster != "" {
+		profile, err = o.fromCluster()
+	} else {
+		var buf []byte
+		if buf, err = app.ReadFile(o.Filename); err == nil {
+			profile, err = app.ParseProfile(o.Name, buf)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	profile.Name = o.Name
+	if err := app.SaveProfile(profile, o.Force); err != nil {
+		return err
+	}
+	o.Println("profile/%s saved", o.Name)
+	return nil
+}
+
+// returns a cobra command
+func NewCommandProfile(options *app.Options) *cobra.Command {
+
+	o := &ProfileOptions{
+		Options: options,
+	}
+
+	cmds := &cobra.Command{
+		Use:                   "profile",
+		Short:                 "Manage cluster profiles (~/.cbctl/profiles)",
+		DisableFlagsInUseLine: true,
+		Run: func(c *cobra.Command, args []string) {
+			c.Help()
+		},
+	}
+
+	// list
+	cmds.AddCommand(&cobra.Command{
+		Use:                   "list [options]",
+		Short:                 "List cluster profiles",
+		DisableFlagsInUseLine: true,
+		Run: func(c *cobra.Command, args []string) {
+			app.ValidateError(c, func() error {
+				list, err := app.ListProfiles()
+				if err != nil {
+					return err
+				}
+				return o.WriteObject(list)
+			}())
+		},
+	})
+
+	// show
+	cmds.AddCommand(&cobra.Command{
+		Use:                   "show (NAME | --name NAME) [options]",
+		Short:                 "Show a cluster profile",
+		Args:                  app.BindCommandArgs(&o.Name),
+		DisableFlagsInUseLine: true,
+		Run: func(c *cobra.Command, args []string) {
+			app.ValidateError(c, func() error {
+				if o.Name == "" {
+					return fmt.Errorf("Name is required.")
+				}
+				profile, err := app.LoadProfile(o.Name)
+				if err != nil {
+					return err
+				}
+				return o.WriteObject(profile)
+			}())
+		},
+	})
+
+	// save
+	cmdSave := &cobra.Command{
+		Use:                   "save (NAME | --name NAME) (--cluster CLUSTER | -f FILENAME) [options]",
+		Short:                 "Save a cluster profile of an existing cluster or a file",
+		Args:                  app.BindCommandArgs(&o.Name),
+		DisableFlagsInUseLine: true,
+		Run: func(c *cobra.Command, args []string) {
+			app.ValidateError(c, o.save())
+		},
+	}
+	cmdSave.Flags().StringVar(&o.Cluster, "cluster", "", "Name of an existing cluster")
+	cmdSave.Flags().BoolVar(&o.Force, "force", false, "Overwrite a profile if exists")
+	cmds.AddCommand(cmdSave)
+
+	return cmds
+}
@@ -0,0 +1,170 @@
+package scale
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/itnpeople/cbctl/app"
+	"github.com/itnpeople/cbctl/client"
+	"github.com/itnpeople/cbctl/utils"
+)
+
+// a struct to support command
+type ScaleOptions struct {
+	*app.Options
+	Connection string
+	Spec       string
+	Replicas   int
+}
+
+// validates
+func (o *ScaleOptions) Validate() error {
+	o.Namespace = utils.NVL(o.Namespace, app.Config.GetCurrentContext().Namespace)
+	if o.Namespace == "" {
+		return fmt.Errorf("Namespace is required.")
+	}
+	if o.Name == "" {
+		return fmt.Errorf("Name is required.")
+	}
+	if o.Connection == "" || o.Spec == "" {
+		return fmt.Errorf("Invalid node-pool flag (connection=%s, spec=%s)", o.Connection, o.Spec)
+	}
+	if o.Replicas < 0 {
+		return fmt.Errorf("Invalid replicas (replicas=%d)", o.Replicas)
+	}
+	return nil
+}
+
+// returns a created time of a node (zero if unknown)
+func createdTime(node client.Node) time.Time {
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02T15:04:05"} {
+		if t, err := time.Parse(layout, node.CreatedTime); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// scales worker nodes of a node-pool (a connection and a spec) to replicas (removes newest nodes first)
+func (o *ScaleOptions) Run() error {
+
+	mcks := client.NewMCKS(app.Config.GetCurrentContext())
+	nodes, err := mcks.ListNodes(o.Namespace, o.Name)
+	if err != nil {
+		return err
+	}
+
+	// worker nodes of the node-pool (newest first), nodes are matched by a location of the connection
+	locations, err := client.NewSpider(app.Config.GetCurrentContext()).ConnectionLocations()
+	if err != nil {
+		return err
+	}
+	if _, ok := locations[o.Connection]; !ok {
+		return &app.ExitError{Code: app.EXIT_NOT_FOUND, Message: fmt.Sprintf("Not found a connection or a region of the connection (connection=%s)", o.Connection)}
+	}
+	pool, err := client.PoolNodes(nodes.Items, client.ROLE_WORKER, o.Connection, o.Spec, locations)
+	if err != nil {
+		return &app.ExitError{Code: app.EXIT_ERROR, Message: fmt.Sprintf("Unable to scale cluster/%s : %v", o.Name, err)}
+	}
+	sort.SliceStable(pool, func(i, j int) bool {
+		ti, tj := createdTime(pool[i]), createdTime(pool[j])
+		if !ti.Equal(tj) {
+			return ti.After(tj)
+		}
+		return pool[i].Name > pool[j].Name
+	})
+
+	// plan
+	current := len(pool)
+	o.Println("cluster/%s node-pool (connection=%s, spec=%s) : %d -> %d", o.Name, o.Connection, o.Spec, current, o.Replicas)
+	switch {
+	case o.Replicas > current:
+		o.Println("  add %d worker nodes", o.Replicas-current)
+	case o.Replicas < current:
+		for _, node := range pool[:current-o.Replicas] {
+			o.Println("  remove node/%s (created=%s)", node.Name, app.None(node.CreatedTime))
+		}
+	default:
+		o.Println("cluster/%s unchanged", o.Name)
+		return nil
+	}
+
+	// apply
+	if o.Replicas > current {
+		body := map[string]interface{}{
+			"worker": []interface{}{
+				map[string]interface{}{"connection": o.Connection, "count": o.Replicas - current, "spec": o.Spec},
+			},
+		}
+		if _, err := mcks.CreateNode(o.Namespace, o.Name, body); err != nil {
+			return err
+		}
+		if !o.IsDryRun() {
+			o.Println("cluster/%s scaled (added=%d)", o.Name, o.Replicas-current)
+		}
+		return nil
+	}
+	objects := []string{}
+	for _, node := range pool[:current-o.Replicas] {
+		objects = append(objects, "node/"+node.Name)
+	}
+	if ok, err := o.Confirm(app.Confirmation{Action: "deleted", Namespace: o.Namespace, Objects: objects}); err != nil {
+		return err
+	} else if !ok {
+		fmt.Fprintln(os.Stderr, "Cancelled")
+		return nil
+	}
+	for i, node := range pool[:current-o.Replicas] {
+		if _, err := mcks.DeleteNode(o.Namespace, o.Name, node.Name); err != nil {
+			return fmt.Errorf("node/%s : %w (removed=%d)", node.Name, err, i)
+		}
+		if !o.IsDryRun() {
+			o.Println("node/%s deleted", node.Name)
+		}
+	}
+	if !o.IsDryRun() {
+		o.Println("cluster/%s scaled (removed=%d)", o.Name, current-o.Replicas)
+	}
+	return nil
+}
+
+// returns a cobra command
+func NewCommandScale(options *app.Options) *cobra.Command {
+
+	o := &ScaleOptions{
+		Options: options,
+	}
+
+	cmds := &cobra.Command{
+		Use:                   "scale",
+		Short:                 "Scale objects",
+		DisableFlagsInUseLine: true,
+		Run: func(c *cobra.Command, args []string) {
+			c.Help()
+		},
+	}
+	o.AddDryRunFlag(cmds)
+	o.AddYesFlag(cmds)
+
+	// cluster
+	cmd := &cobra.Command{
+		Use:                   "cluster (NAME | --name NAME) --connection CONNECTION --spec SPEC --replicas COUNT [options]",
+		Short:                 "Scale worker nodes of a node-pool (a connection and a spec) of a cluster",
+		Args:                  app.BindCommandArgs(&o.Name),
+		DisableFlagsInUseLine: true,
+		Run: func(c *cobra.Command, args []string) {
+			app.ValidateError(c, o.Validate())
+			app.ValidateError(c, o.Run())
+		},
+	}
+	cmd.Flags().StringVar(&o.Connection, "connection", "", "Connection name of worker nodes")
+	cmd.Flags().StringVar(&o.Spec, "spec", "", "Spec. of worker nodes")
+	cmd.Flags().IntVar(&o.Replicas, "replicas", -1, "Count of worker nodes of the node-pool")
+	cmds.AddCommand(cmd)
+
+	return cmds
+}
@@ -3,11 +3,11 @@ package updatekubeconfig
 import (
 	"fmt"
 
-	"github.com/go-resty/resty/v2"
 	"github.com/spf13/cobra"
 	"k8s.io/client-go/tools/clientcmd"
 
 	"github.com/itnpeople/cbctl/app"
+	"github.com/itnpeople/cbctl/client"
 	"github.com/itnpeople/cbctl/utils"
 )
 
@@ -40,14 +40,10 @@ func NewCommandUpdateKubeconfig(options *app.Options) *cobra.Command {
 				}
 
 				// execute
-				url := fmt.Sprintf("%s/ns/%s/clusters/%s", app.Config.GetCurrentContext().Urls.MCKS, o.Namespace, o.Name)
-				res := &struct {
-					ClusterConfig string `json:"clusterConfig"`
-				}{}
-				if resp, err := resty.New().SetDisableWarn(true).R().SetResult(res).Get(url); err != nil {
+				if cluster, err := client.NewMCKS(app.Config.GetCurrentContext()).GetCluster(o.Namespace, o.Name); err != nil {
 					return err
-				} else if res.ClusterConfig != "" {
-					conf, err := clientcmd.Load([]byte(res.ClusterConfig))
+				} else if cluster.ClusterConfig != "" {
+					conf, err := clientcmd.Load([]byte(cluster.ClusterConfig))
 					if err != nil {
 						return err
 					}
@@ -69,8 +65,8 @@ func NewCommandUpdateKubeconfig(options *app.Options) *cobra.Command {
 						return fmt.Errorf("cannot load kubeconfig (cause=%v)", err)
 					}
 					o.Println("Success...")
-				} else {
-					o.WriteBody(resp.Body())
+				} else if err := o.WriteObject(cluster); err != nil {
+					return err
 				}
 				return nil
 
@@ -0,0 +1,102 @@
+package validate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/itnpeople/cbctl/app"
+	"github.com/itnpeople/cbctl/schema"
+)
+
+// fields of a manifest (apply) that are not a part of a request body
+var manifestFields = []string{"kind", "namespace"}
+
+// a struct to support command
+type ValidateOptions struct {
+	*app.Options
+	Kind string
+}
+
+// validates documents of a file (kind : a "kind" field of a document or --kind)
+func (o *ValidateOptions) Run() error {
+
+	if o.Filename == "" {
+		return fmt.Errorf("Filename is required.")
+	}
+	buf, err := app.ReadFile(o.Filename)
+	if err != nil {
+		return err
+	}
+	docs, err := app.SplitDocuments(buf)
+	if err != nil {
+		return err
+	}
+
+	invalid := 0
+	for i, doc := range docs {
+		body := map[string]interface{}{}
+		if err := json.Unmarshal(doc, &body); err != nil {
+			return fmt.Errorf("Invalid document (document=%d, cause=%v)", i+1, err)
+		}
+		kind, _ := body["kind"].(string)
+		kind = schema.KindOf(kind)
+		if kind == "" {
+			kind = schema.KindOf(o.Kind)
+		}
+		if kind == "" {
+			return fmt.Errorf("Kind is required, a \"kind\" field or --kind (document=%d, allowed=%s)", i+1, strings.Join(schema.KINDS, ","))
+		}
+		for _, f := range manifestFields {
+			delete(body, f)
+		}
+		if kind == "node" {
+			delete(body, "cluster")
+		}
+		b, _ := json.Marshal(body)
+
+		var verr *schema.ValidationError
+		warnings, err := schema.Validate(kind, b)
+		if errors.As(err, &verr) {
+			invalid++
+			o.Println("document %d (%s): invalid", i+1, kind)
+			for _, v := range verr.Violations {
+				o.Println("  %s", v.String())
+			}
+		} else if err != nil {
+			return fmt.Errorf("document %d : %w", i+1, err)
+		} else {
+			o.Println("document %d (%s): valid", i+1, kind)
+		}
+		for _, w := range warnings {
+			o.Println("  %s", w.String())
+		}
+	}
+	if invalid > 0 {
+		return &app.ExitError{Code: app.EXIT_VALIDATION, Message: fmt.Sprintf("%d of %d documents are invalid", invalid, len(docs))}
+	}
+	return nil
+}
+
+// returns a cobra command
+func NewCommandValidate(options *app.Options) *cobra.Command {
+
+	o := &ValidateOptions{
+		Options: options,
+	}
+
+	cmd := &cobra.Command{
+		Use:                   "validate -f FILENAME [--kind KIND] [options]",
+		Short:                 "Validate manifests against schemas without sending requests",
+		DisableFlagsInUseLine: true,
+		Run: func(c *cobra.Command, args []string) {
+			app.ValidateError(c, o.Run())
+		},
+	}
+	cmd.Flags().StringVar(&o.Kind, "kind", "", fmt.Sprintf("Kind of documents without a \"kind\" field (%s)", strings.Join(schema.KINDS, ", ")))
+
+	return cmd
+}
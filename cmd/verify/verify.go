@@ -0,0 +1,57 @@
+package verify
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/itnpeople/cbctl/app"
+	"github.com/itnpeople/cbctl/client"
+)
+
+// verifies a connection and prints results (returns an error if a check failed)
+func VerifyConnection(o *app.Options, name string) error {
+
+	res, err := client.NewSpider(app.Config.GetCurrentContext()).VerifyConnection(name)
+	if err != nil {
+		return err
+	}
+	if err := o.WriteObject(res); err != nil {
+		return err
+	}
+	if res.Failed() {
+		return &app.ExitError{Code: app.EXIT_FAILED, Message: fmt.Sprintf("connection '%s' verification failed", name)}
+	}
+	return nil
+}
+
+// returns a cobra command
+func NewCommandVerify(options *app.Options) *cobra.Command {
+
+	cmds := &cobra.Command{
+		Use:                   "verify",
+		Short:                 "Verify objects work",
+		DisableFlagsInUseLine: true,
+		Run: func(c *cobra.Command, args []string) {
+			c.Help()
+		},
+	}
+
+	// connection
+	cmds.AddCommand(&cobra.Command{
+		Use:                   "connection (NAME | --name NAME) [options]",
+		Short:                 "Verify a cloud connection with read-only calls to the CSP (vm specs, vpcs)",
+		Args:                  app.BindCommandArgs(&options.Name),
+		DisableFlagsInUseLine: true,
+		Run: func(c *cobra.Command, args []string) {
+			app.ValidateError(c, func() error {
+				if options.Name == "" {
+					return fmt.Errorf("Name is required.")
+				}
+				return VerifyConnection(options, options.Name)
+			}())
+		},
+	})
+
+	return cmds
+}
@@ -0,0 +1,210 @@
+package wait
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/itnpeople/cbctl/app"
+	"github.com/itnpeople/cbctl/client"
+	"github.com/itnpeople/cbctl/utils"
+)
+
+const FOR_DELETE = "delete"
+
+// a struct to support command
+type WaitOptions struct {
+	*app.Options
+	For         string
+	Timeout     time.Duration
+	Interval    time.Duration
+	clusterName string
+}
+
+// validates
+func (o *WaitOptions) Validate() error {
+	o.Namespace = utils.NVL(o.Namespace, app.Config.GetCurrentContext().Namespace)
+	if o.Namespace == "" {
+		return fmt.Errorf("Namespace is required.")
+	}
+	if o.Name == "" {
+		return fmt.Errorf("Name is required.")
+	}
+	if o.For != FOR_DELETE && !strings.Contains(o.For, "=") {
+		return fmt.Errorf("Invalid condition (for=%s, allowed=delete|FIELD=VALUE)", o.For)
+	}
+	if o.Timeout <= 0 || o.Interval <= 0 {
+		return fmt.Errorf("Invalid timeout or interval (timeout=%v, interval=%v)", o.Timeout, o.Interval)
+	}
+	return nil
+}
+
+// polls a object until the condition is met (failures : terminal failure states of the object)
+func (o *WaitOptions) Run(kind string, fetch func() (interface{}, error), failures ...string) error {
+
+	field, want := "", ""
+	if o.For != FOR_DELETE {
+		kv := strings.SplitN(o.For, "=", 2)
+		field, want = strings.TrimPrefix(kv[0], "."), kv[1]
+	}
+
+	deadline := time.Now().Add(o.Timeout)
+	for {
+		obj, err := fetch()
+		if o.For == FOR_DELETE {
+			if client.IsNotFound(err) {
+				o.Println("%s/%s deleted", kind, o.Name)
+				return nil
+			}
+		} else if client.IsNotFound(err) {
+			return err
+		}
+		if reason := client.ReasonOf(err); reason != client.REASON_UNKNOWN && reason != client.REASON_SERVER && reason != client.REASON_NOT_FOUND {
+			return err
+		}
+
+		if err == nil && field != "" {
+			value, err := valueOf(obj, field)
+			if err != nil {
+				return err
+			}
+			if matches(value, want) {
+				o.Println("%s/%s condition met (%s=%s)", kind, o.Name, field, value)
+				return nil
+			}
+			for _, failure := range failures {
+				if matches(value, failure) && !matches(failure, want) {
+					return &app.ExitError{Code: app.EXIT_FAILED, Message: fmt.Sprintf("%s/%s reached a terminal state (%s=%s)", kind, o.Name, field, value)}
+				}
+			}
+		}
+
+		// sleeps until the next poll (the last poll is at the deadline)
+		wait := time.Until(deadline)
+		if wait <= 0 {
+			return &app.ExitError{Code: app.EXIT_TIMEOUT, Message: fmt.Sprintf("timed out waiting for the condition on %s/%s (for=%s, timeout=%v)", kind, o.Name, o.For, o.Timeout)}
+		}
+		if wait > o.Interval {
+			wait = o.Interval
+		}
+		time.Sleep(wait)
+	}
+
+}
+
+// returns a value of the field path (eg. "status", "vm.0.status")
+func valueOf(obj interface{}, path string) (string, error) {
+
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return "", err
+	}
+	var data interface{}
+	if err := json.Unmarshal(b, &data); err != nil {
+		return "", err
+	}
+	for _, key := range strings.Split(path, ".") {
+		switch v := data.(type) {
+		case map[string]interface{}:
+			data = nil
+			for k := range v {
+				if strings.EqualFold(k, key) {
+					data = v[k]
+				}
+			}
+		case []interface{}:
+			i := 0
+			if _, err := fmt.Sscanf(key, "%d", &i); err != nil || i < 0 || i >= len(v) {
+				return "", fmt.Errorf("Invalid field path (path=%s)", path)
+			}
+			data = v[i]
+		default:
+			data = nil
+		}
+		if data == nil {
+			return "", fmt.Errorf("Not found a field (path=%s)", path)
+		}
+	}
+	return fmt.Sprint(data), nil
+}
+
+// case-insensitive (a MCIS status includes a count of VMs eg. "Running-(3/3)")
+func matches(value string, want string) bool {
+	value, want = strings.ToLower(value), strings.ToLower(want)
+	return value == want || strings.HasPrefix(value, want+"-")
+}
+
+// returns a cobra command
+func NewCommandWait(options *app.Options) *cobra.Command {
+
+	o := &WaitOptions{
+		Options: options,
+	}
+
+	// wait
+	cmds := &cobra.Command{
+		Use:   "wait",
+		Short: "Wait for a specific condition on objects",
+		Run: func(c *cobra.Command, args []string) {
+			c.Help()
+		},
+	}
+	cmds.PersistentFlags().StringVar(&o.For, "for", "", "Condition to wait on (delete, FIELD=VALUE eg. status=completed)")
+	cmds.PersistentFlags().DurationVar(&o.Timeout, "timeout", 10*time.Minute, "Timeout of waiting")
+	cmds.PersistentFlags().DurationVar(&o.Interval, "interval", 10*time.Second, "Polling interval")
+
+	// cluster
+	cmds.AddCommand(&cobra.Command{
+		Use:                   "cluster (NAME | --name NAME) --for CONDITION [options]",
+		Short:                 "Wait for a cluster",
+		Args:                  app.BindCommandArgs(&o.Name),
+		DisableFlagsInUseLine: true,
+		Run: func(c *cobra.Command, args []string) {
+			app.ValidateError(c, o.Validate())
+			app.ValidateError(c, o.Run("cluster", func() (interface{}, error) {
+				return client.NewMCKS(app.Config.GetCurrentContext()).GetCluster(o.Namespace, o.Name)
+			}, "Failed"))
+		},
+	})
+
+	// node
+	cmdNode := &cobra.Command{
+		Use:                   "node (NAME | --name NAME) --cluster CLUSTER_NAME --for CONDITION [options]",
+		Short:                 "Wait for a node",
+		Args:                  app.BindCommandArgs(&o.Name),
+		DisableFlagsInUseLine: true,
+		Run: func(c *cobra.Command, args []string) {
+			app.ValidateError(c, o.Validate())
+			app.ValidateError(c, func() error {
+				if o.clusterName == "" {
+					return fmt.Errorf("Cluster name is required.")
+				}
+				return nil
+			}())
+			app.ValidateError(c, o.Run("node", func() (interface{}, error) {
+				return client.NewMCKS(app.Config.GetCurrentContext()).GetNode(o.Namespace, o.clusterName, o.Name)
+			}, "Failed"))
+		},
+	}
+	cmdNode.Flags().StringVar(&o.clusterName, "cluster", "", "Name of cluster")
+	cmds.AddCommand(cmdNode)
+
+	// mcis
+	cmds.AddCommand(&cobra.Command{
+		Use:                   "mcis (NAME | --name NAME) --for CONDITION [options]",
+		Short:                 "Wait for a MCIS",
+		Args:                  app.BindCommandArgs(&o.Name),
+		DisableFlagsInUseLine: true,
+		Run: func(c *cobra.Command, args []string) {
+			app.ValidateError(c, o.Validate())
+			app.ValidateError(c, o.Run("mcis", func() (interface{}, error) {
+				return client.NewTumblebug(app.Config.GetCurrentContext()).GetMCIS(o.Namespace, o.Name)
+			}, "Failed", "Terminated"))
+		},
+	})
+
+	return cmds
+}
@@ -0,0 +1,297 @@
+package schema
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/itnpeople/cbctl/app"
+)
+
+// kinds of objects cbctl can create
+var KINDS = []string{"cluster", "node", "driver", "credential", "region", "connection", "namespace"}
+
+//go:embed schemas/*.json
+var files embed.FS
+
+// a subset of JSON schema (type, properties, required, additionalProperties, items, enum, minimum, minLength, minItems, pattern, format, $ref)
+type Schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 string             `json:"type"`
+	Properties           map[string]*Schema `json:"properties"`
+	Required             []string           `json:"required"`
+	AdditionalProperties *bool              `json:"additionalProperties"`
+	Items                *Schema            `json:"items"`
+	Enum                 []interface{}      `json:"enum"`
+	Minimum              *float64           `json:"minimum"`
+	MinLength            *int               `json:"minLength"`
+	MinItems             *int               `json:"minItems"`
+	Pattern              string             `json:"pattern"`
+	Format               string             `json:"format"`
+}
+
+// a violation of a schema (a warning doesn't fail a validation, eg. an unknown field)
+type Violation struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+	Warning bool   `json:"warning,omitempty"`
+}
+
+func (v Violation) String() string {
+	if v.Warning {
+		return fmt.Sprintf("%s: %s (warning)", v.Path, v.Message)
+	}
+	return fmt.Sprintf("%s: %s", v.Path, v.Message)
+}
+
+// an error of violations
+type ValidationError struct {
+	Kind       string
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	lines := []string{fmt.Sprintf("Invalid %s manifest (violations=%d)", e.Kind, len(e.Violations))}
+	for _, v := range e.Violations {
+		lines = append(lines, "  "+v.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (e *ValidationError) ExitCode() int {
+	return app.EXIT_VALIDATION
+}
+
+// returns a request body like app.GetBody and validates a body of a file (-f) against a schema of the kind (warnings go to stderr)
+func GetBody(o app.IOptions, kind string, tpl string) ([]byte, error) {
+	buf, err := app.GetBody(o, tpl)
+	if err != nil {
+		return nil, err
+	}
+	if o.GetFilename() != "" {
+		warnings, err := Validate(kind, buf)
+		if err != nil {
+			return nil, err
+		}
+		PrintWarnings(kind, warnings)
+	}
+	return buf, nil
+}
+
+// prints warnings of a validation to stderr
+func PrintWarnings(object string, warnings []Violation) {
+	for _, w := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s %s: %s\n", object, w.Path, w.Message)
+	}
+}
+
+// returns a kind of a schema by a kind of a manifest (eg. "NodePool" -> "node", "Cluster" -> "cluster")
+func KindOf(kind string) string {
+	if strings.EqualFold(kind, "NodePool") {
+		return "node"
+	}
+	return strings.ToLower(kind)
+}
+
+// returns an embedded schema by a name (a kind or a reference)
+func Load(name string) (*Schema, error) {
+	b, err := files.ReadFile(path.Join("schemas", strings.ToLower(name)+".json"))
+	if err != nil {
+		return nil, fmt.Errorf("Not supported kind (kind=%s, allowed=%s)", name, strings.Join(KINDS, ","))
+	}
+	s := &Schema{}
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, fmt.Errorf("Invalid schema (name=%s, cause=%v)", name, err)
+	}
+	return s, nil
+}
+
+// validates a json document against a schema of the kind (returns warnings, and a *ValidationError if violated)
+func Validate(kind string, doc []byte) ([]Violation, error) {
+
+	s, err := Load(kind)
+	if err != nil {
+		return nil, err
+	}
+	var data interface{}
+	if err := json.Unmarshal(doc, &data); err != nil {
+		return nil, &ValidationError{Kind: kind, Violations: []Violation{{Path: ".", Message: fmt.Sprintf("invalid json (%v)", err)}}}
+	}
+	violations := []Violation{}
+	if err := s.validate("", data, &violations); err != nil {
+		return nil, err
+	}
+	errs, warnings := []Violation{}, []Violation{}
+	for _, v := range violations {
+		if v.Warning {
+			warnings = append(warnings, v)
+		} else {
+			errs = append(errs, v)
+		}
+	}
+	if len(errs) > 0 {
+		return warnings, &ValidationError{Kind: kind, Violations: errs}
+	}
+	return warnings, nil
+}
+
+func (s *Schema) validate(p string, data interface{}, violations *[]Violation) error {
+
+	if s.Ref != "" {
+		ref, err := Load(s.Ref)
+		if err != nil {
+			return err
+		}
+		return ref.validate(p, data, violations)
+	}
+	add := func(format string, params ...interface{}) {
+		*violations = append(*violations, Violation{Path: pathOf(p), Message: fmt.Sprintf(format, params...)})
+	}
+
+	// type
+	if s.Type != "" && !isType(s.Type, data) {
+		add("expected %s, got %s", s.Type, typeOf(data))
+		return nil
+	}
+
+	// enum
+	if len(s.Enum) > 0 {
+		found, allowed := false, []string{}
+		for _, e := range s.Enum {
+			found = found || (e == data)
+			allowed = append(allowed, fmt.Sprintf("%q", e))
+		}
+		if !found {
+			add("unsupported value %s (allowed=%s)", toJSON(data), strings.Join(allowed, ","))
+		}
+	}
+
+	switch v := data.(type) {
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				*violations = append(*violations, Violation{Path: pathOf(p + "." + name), Message: "required field is missing"})
+			}
+		}
+		keys := []string{}
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if prop, ok := s.Properties[k]; ok {
+				if err := prop.validate(p+"."+k, v[k], violations); err != nil {
+					return err
+				}
+			} else if s.AdditionalProperties == nil && len(s.Properties) > 0 {
+				// unknown fields are warnings (fields of newer servers), errors only if "additionalProperties" is false
+				*violations = append(*violations, Violation{Path: pathOf(p + "." + k), Message: s.unknownField(k), Warning: true})
+			} else if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				*violations = append(*violations, Violation{Path: pathOf(p + "." + k), Message: s.unknownField(k)})
+			}
+		}
+	case []interface{}:
+		if s.MinItems != nil && len(v) < *s.MinItems {
+			add("expected at least %d items, got %d", *s.MinItems, len(v))
+		}
+		if s.Items != nil {
+			for i, e := range v {
+				if err := s.Items.validate(fmt.Sprintf("%s[%d]", p, i), e, violations); err != nil {
+					return err
+				}
+			}
+		}
+	case string:
+		if s.MinLength != nil && len(v) < *s.MinLength {
+			add("expected at least %d characters", *s.MinLength)
+		}
+		if s.Pattern != "" && v != "" {
+			if re, err := regexp.Compile(s.Pattern); err == nil && !re.MatchString(v) {
+				add("%q does not match a pattern %s", v, s.Pattern)
+			}
+		}
+		if s.Format == "cidr" {
+			if _, _, err := net.ParseCIDR(v); err != nil {
+				add("%q is not a CIDR (eg. 10.244.0.0/16)", v)
+			}
+		}
+	case float64:
+		if s.Minimum != nil && v < *s.Minimum {
+			add("expected a value >= %v, got %v", *s.Minimum, v)
+		}
+	}
+	return nil
+}
+
+// returns a message of an unknown field (suggests a property of a different case)
+func (s *Schema) unknownField(k string) string {
+	for name := range s.Properties {
+		if strings.EqualFold(name, k) {
+			return fmt.Sprintf("unknown field (did you mean %q?)", name)
+		}
+	}
+	return "unknown field"
+}
+
+// returns whether a value is a type of JSON schema
+func isType(t string, data interface{}) bool {
+	switch t {
+	case "object":
+		_, ok := data.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := data.([]interface{})
+		return ok
+	case "string":
+		_, ok := data.(string)
+		return ok
+	case "integer":
+		f, ok := data.(float64)
+		return ok && f == math.Trunc(f)
+	case "number":
+		_, ok := data.(float64)
+		return ok
+	case "boolean":
+		_, ok := data.(bool)
+		return ok
+	}
+	return true
+}
+
+func typeOf(data interface{}) string {
+	switch data.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	}
+	return fmt.Sprintf("%T", data)
+}
+
+func toJSON(data interface{}) string {
+	b, _ := json.Marshal(data)
+	return string(b)
+}
+
+// returns a field path (eg. ".controlPlane[0].count", "." for the root)
+func pathOf(p string) string {
+	if p == "" {
+		return "."
+	}
+	return p
+}
@@ -0,0 +1,87 @@
+package schema
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		kind     string
+		doc      string
+		errors   []string
+		warnings []string
+	}{
+		{"valid cluster", "cluster", `{"name": "c1", "controlPlane": [{"connection": "config-aws", "count": 1, "spec": "t2.medium"}], "worker": [{"connection": "config-aws", "count": 1, "spec": "t2.medium"}], "config": {"kubernetes": {"networkCni": "canal", "podCidr": "10.244.0.0/16"}}}`, nil, nil},
+		{"required fields", "cluster", `{"name": "c1", "controlplane": []}`, []string{".controlPlane", ".worker"}, []string{".controlplane"}},
+		{"types", "cluster", `{"name": "c1", "controlPlane": [{"connection": "config-aws", "count": "1", "spec": "t2.medium"}], "worker": [{"connection": "config-aws", "count": 1.5, "spec": "t2.medium"}]}`, []string{".controlPlane[0].count", ".worker[0].count"}, nil},
+		{"enums, patterns and cidrs", "cluster", `{"name": "C1", "controlPlane": [], "worker": [], "config": {"kubernetes": {"networkCni": "calico", "podCidr": "10.244.0.0"}}}`, []string{".config.kubernetes.networkCni", ".config.kubernetes.podCidr", ".controlPlane", ".name", ".worker"}, nil},
+		{"unknown fields of newer servers", "cluster", `{"name": "c1", "controlPlane": [{"connection": "config-aws", "count": 1, "spec": "t2.medium"}], "worker": [{"connection": "config-aws", "count": 1, "spec": "t2.medium"}], "config": {"kubernetes": {"etcd": "external"}}}`, nil, []string{".config.kubernetes.etcd"}},
+		{"a reference", "credential", `{"CredentialName": "cred", "ProviderName": "AWS", "KeyValueInfoList": [{"Key": "ClientId"}]}`, []string{".KeyValueInfoList[0].Value"}, nil},
+		{"not an object", "namespace", `[]`, []string{"."}, nil},
+		{"invalid json", "namespace", `{`, []string{"."}, nil},
+	}
+	for _, tt := range tests {
+		warnings, err := Validate(tt.kind, []byte(tt.doc))
+		var verr *ValidationError
+		if err != nil && !errors.As(err, &verr) {
+			t.Errorf("%s: Validate() : %v", tt.name, err)
+			continue
+		}
+		got := []string{}
+		if verr != nil {
+			for _, v := range verr.Violations {
+				got = append(got, v.Path)
+			}
+		}
+		if !equals(got, tt.errors) {
+			t.Errorf("%s: Validate() errors = %v, want %v", tt.name, got, tt.errors)
+		}
+		got = []string{}
+		for _, w := range warnings {
+			got = append(got, w.Path)
+		}
+		if !equals(got, tt.warnings) {
+			t.Errorf("%s: Validate() warnings = %v, want %v", tt.name, got, tt.warnings)
+		}
+	}
+}
+
+func TestValidateAdditionalProperties(t *testing.T) {
+	no := false
+	s := &Schema{Type: "object", AdditionalProperties: &no, Properties: map[string]*Schema{"name": {Type: "string"}}}
+	violations := []Violation{}
+	if err := s.validate("", map[string]interface{}{"Name": "x"}, &violations); err != nil {
+		t.Fatal(err)
+	}
+	if len(violations) != 1 || violations[0].Warning || violations[0].Message != `unknown field (did you mean "name"?)` {
+		t.Errorf("validate() = %v", violations)
+	}
+}
+
+func TestValidateUnsupportedKind(t *testing.T) {
+	if _, err := Validate("mcis", []byte(`{}`)); err == nil {
+		t.Error("Validate() of an unsupported kind expected an error")
+	}
+}
+
+func TestKindOf(t *testing.T) {
+	for kind, want := range map[string]string{"NodePool": "node", "Cluster": "cluster", "": ""} {
+		if got := KindOf(kind); got != want {
+			t.Errorf("KindOf(%q) = %q, want %q", kind, got, want)
+		}
+	}
+}
+
+func equals(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -27,11 +27,18 @@ func ToPrettyJSON(data []byte) []byte {
 	return data
 }
 
+// escapes a string to be a content of a json string (eg. a private-key with new-lines)
+func EscapeJSON(str string) string {
+	b, _ := json.Marshal(str)
+	return string(b[1 : len(b)-1])
+}
+
 func ToTemplateBytes(tpl string, todo interface{}) ([]byte, error) {
 
 	t, err := template.New("tpl").Funcs(
 		template.FuncMap{
-			"ToUpper": strings.ToUpper,
+			"ToUpper":    strings.ToUpper,
+			"EscapeJSON": EscapeJSON,
 		}).Parse(tpl)
 	if err != nil {
 		return nil, err